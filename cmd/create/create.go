@@ -53,6 +53,7 @@ func init() {
 }
 
 func preRunCheckFile(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
 
 	if fileFlag != "" {
 		if dryRunFlag {
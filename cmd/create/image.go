@@ -22,6 +22,8 @@ import (
 	"strings"
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
 	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
 	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
@@ -65,34 +67,29 @@ Examples:
   # Create an image from a YAML or JSON file with a different name
   zstack-cli create image override-name -f image-spec.yaml`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
 		filePath, _ := cmd.Flags().GetString("file")
 		if filePath != "" {
-			createImageFromFile(cmd, name, filePath)
-			return
+			return createImageFromFile(cmd, name, filePath)
 		}
 
-		createImageFromFlags(cmd, name)
+		return createImageFromFlags(cmd, name)
 	},
 }
 
-func createImageFromFlags(cmd *cobra.Command, name string) {
+func createImageFromFlags(cmd *cobra.Command, name string) error {
 
 	url, _ := cmd.Flags().GetString("url")
 	backupStorageStr, _ := cmd.Flags().GetString("image-storage")
 
 	if url == "" {
-		fmt.Println("Error: required flag --url not set")
-		cmd.Help()
-		return
+		return fmt.Errorf("required flag --url not set")
 	}
 
 	if backupStorageStr == "" {
-		fmt.Println("Error: required flag --image-storage not set")
-		cmd.Help()
-		return
+		return fmt.Errorf("required flag --image-storage not set")
 	}
 
 	backupStorageNames := strings.Split(backupStorageStr, ",")
@@ -100,15 +97,13 @@ func createImageFromFlags(cmd *cobra.Command, name string) {
 
 	cli := client.GetClient()
 	if cli == nil {
-		fmt.Println("Error: Not logged in. Please run 'zstack-cli login' first.")
-		return
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
 	}
 
 	for _, nameOrUUID := range backupStorageNames {
 		uuid, err := client.GetBackupStorageUUIDByName(cli, nameOrUUID)
 		if err != nil {
-			fmt.Printf("Error: %s\n", err)
-			return
+			return err
 		}
 		backupStorageUuids = append(backupStorageUuids, uuid)
 	}
@@ -148,24 +143,36 @@ func createImageFromFlags(cmd *cobra.Command, name string) {
 
 	if dryRunFlag {
 		utils.PrintDryRun(imageParam, outputFlag)
-		return
+		return nil
 	}
 
 	fmt.Printf("Creating image '%s'...\n", name)
 	result, err := cli.AddImage(imageParam)
 	if err != nil {
-		fmt.Printf("Error creating image: %s\n", err)
-		return
+		return fmt.Errorf("failed to create image: %s", err)
+	}
+
+	waiter := common.NewWaiter(cmd, os.Stdout)
+	if waiter.Enabled {
+		name := fmt.Sprintf("image %s (%s)", result.Name, result.UUID)
+		err = waiter.Until(name, common.PollImageStatus(cli, result.UUID), []string{types.ImageStatusReady}, []string{types.ImageStatusDeleted})
+		if err != nil {
+			return fmt.Errorf("failed to wait for image: %w", err)
+		}
+		result, err = cli.GetImage(result.UUID)
+		if err != nil {
+			return fmt.Errorf("failed to get image: %s", err)
+		}
 	}
 
 	utils.PrintOperationResult("Image", result, outputFlag)
+	return nil
 }
 
-func createImageFromFile(cmd *cobra.Command, name string, filePath string) {
+func createImageFromFile(cmd *cobra.Command, name string, filePath string) error {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		fmt.Printf("Error reading file %s: %v\n", filePath, err)
-		return
+		return fmt.Errorf("failed to read file %s: %v", filePath, err)
 	}
 
 	var imageSpec ImageSpec
@@ -178,12 +185,10 @@ func createImageFromFile(cmd *cobra.Command, name string, filePath string) {
 
 			specData, err := json.Marshal(resourceSpec.Spec)
 			if err != nil {
-				fmt.Printf("Error converting resource spec: %v\n", err)
-				return
+				return fmt.Errorf("failed to convert resource spec: %v", err)
 			}
 			if err := json.Unmarshal(specData, &imageSpec); err != nil {
-				fmt.Printf("Error parsing image spec from generic format: %v\n", err)
-				return
+				return fmt.Errorf("failed to parse image spec from generic format: %v", err)
 			}
 			if name == "" {
 				name = resourceSpec.Metadata.Name
@@ -194,39 +199,33 @@ func createImageFromFile(cmd *cobra.Command, name string, filePath string) {
 	if !isGenericFormat {
 		if strings.HasSuffix(filePath, ".json") {
 			if err := json.Unmarshal(data, &imageSpec); err != nil {
-				fmt.Printf("Error parsing JSON file: %v\n", err)
-				return
+				return fmt.Errorf("failed to parse JSON file: %v", err)
 			}
 		} else {
 			if err := yaml.Unmarshal(data, &imageSpec); err != nil {
-				fmt.Printf("Error parsing YAML file: %v\n", err)
-				return
+				return fmt.Errorf("failed to parse YAML file: %v", err)
 			}
 		}
 	}
 
 	if imageSpec.URL == "" {
-		fmt.Println("Error: URL is required in image specification")
-		return
+		return fmt.Errorf("URL is required in image specification")
 	}
 
 	if len(imageSpec.BackupStorageNames) == 0 {
-		fmt.Println("Error: backupStorageUuids is required in image specification")
-		return
+		return fmt.Errorf("backupStorageUuids is required in image specification")
 	}
 
 	cli := client.GetClient()
 	if cli == nil {
-		fmt.Println("Error: Not logged in. Please run 'zstack-cli login' first.")
-		return
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
 	}
 
 	backupStorageUuids := make([]string, 0, len(imageSpec.BackupStorageNames))
 	for _, nameOrUUID := range imageSpec.BackupStorageNames {
 		uuid, err := client.GetBackupStorageUUIDByName(cli, nameOrUUID)
 		if err != nil {
-			fmt.Printf("Error: %s\n", err)
-			return
+			return err
 		}
 		backupStorageUuids = append(backupStorageUuids, uuid)
 	}
@@ -253,17 +252,30 @@ func createImageFromFile(cmd *cobra.Command, name string, filePath string) {
 
 	if dryRunFlag {
 		utils.PrintDryRun(imageParam, outputFlag)
-		return
+		return nil
 	}
 
 	fmt.Printf("Creating image '%s' from file...\n", name)
 	result, err := cli.AddImage(imageParam)
 	if err != nil {
-		fmt.Printf("Error creating image: %s\n", err)
-		return
+		return fmt.Errorf("failed to create image: %s", err)
+	}
+
+	waiter := common.NewWaiter(cmd, os.Stdout)
+	if waiter.Enabled {
+		name := fmt.Sprintf("image %s (%s)", result.Name, result.UUID)
+		err = waiter.Until(name, common.PollImageStatus(cli, result.UUID), []string{types.ImageStatusReady}, []string{types.ImageStatusDeleted})
+		if err != nil {
+			return fmt.Errorf("failed to wait for image: %w", err)
+		}
+		result, err = cli.GetImage(result.UUID)
+		if err != nil {
+			return fmt.Errorf("failed to get image: %s", err)
+		}
 	}
 
 	utils.PrintOperationResult("Image", result, outputFlag)
+	return nil
 }
 
 func init() {
@@ -286,4 +298,5 @@ func init() {
 	imageCmd.Flags().StringSlice("tag", []string{}, "Tag UUID list")
 	imageCmd.Flags().StringSlice("system-tag", []string{}, "System tag list")
 	imageCmd.Flags().StringSlice("user-tag", []string{}, "User tag list")
+	common.AddWaitFlags(imageCmd)
 }
@@ -21,9 +21,13 @@ import (
 	"strings"
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
 	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
 	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
 	"gopkg.in/yaml.v3"
 )
 
@@ -52,6 +56,9 @@ type VmInstanceSpec struct {
 	DataVolumeSystemTags []string `json:"dataVolumeSystemTags" yaml:"dataVolumeSystemTags"`
 	SystemTags           []string `json:"systemTags" yaml:"systemTags"`
 	UserTags             []string `json:"userTags" yaml:"userTags"`
+	SSHKeyFile           string   `json:"sshKeyFile" yaml:"sshKeyFile"`
+	RootPassword         string   `json:"rootPassword" yaml:"rootPassword"`
+	UserData             string   `json:"userData" yaml:"userData"`
 }
 
 var instanceCmd = &cobra.Command{
@@ -66,13 +73,19 @@ Examples:
   # Create VM instance with custom CPU and memory
   zstack-cli create instance my-vm --image 2162b130d30c49f2a3aad8585517e668 --cpu 4 --memory 8G --l3-network 2162b130d30c49f2a3aad8585517e668
 
+  # Create VM instance with an SSH key installed by cloud-init
+  zstack-cli create instance my-vm --image ubuntu-22.04 --instance-offering small --l3-network public --ssh-key-file ~/.ssh/id_ed25519.pub
+
+  # Create VM instance bootstrapped by cloud-init
+  zstack-cli create instance my-vm --image ubuntu-22.04 --instance-offering small --l3-network public --user-data-file cloud-config.yaml
+
   # Create VM instance from configuration file
   zstack-cli create instance -f vm-spec.yaml
 
   # Create VM instance in stopped state
   zstack-cli create instance my-vm --image 2162b130d30c49f2a3aad8585517e668 --instance-offering 2162b130d30c49f2a3aad8585517e668 --l3-network 2162b130d30c49f2a3aad8585517e668 --strategy CreateStopped`,
 	Args: cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		filePath, _ := cmd.Flags().GetString("file")
 
 		var name string
@@ -81,23 +94,19 @@ Examples:
 		}
 
 		if filePath != "" {
-			createVmInstanceFromFile(cmd, name, filePath)
-		} else {
-			if name == "" {
-				fmt.Println("Error: VM instance name is required when not using --file")
-				cmd.Help()
-				return
-			}
-			createVmInstanceFromFlags(cmd, name)
+			return createVmInstanceFromFile(cmd, name, filePath)
 		}
+		if name == "" {
+			return fmt.Errorf("VM instance name is required when not using --file")
+		}
+		return createVmInstanceFromFlags(cmd, name)
 	},
 }
 
-func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string) {
+func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string) error {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		fmt.Printf("Error reading file %s: %v\n", filePath, err)
-		return
+		return fmt.Errorf("failed to read file %s: %v", filePath, err)
 	}
 
 	var vmSpec VmInstanceSpec
@@ -110,12 +119,10 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 			isGenericFormat = true
 			specData, err := json.Marshal(resourceSpec.Spec)
 			if err != nil {
-				fmt.Printf("Error converting resource spec: %v\n", err)
-				return
+				return fmt.Errorf("failed to convert resource spec: %v", err)
 			}
 			if err := json.Unmarshal(specData, &vmSpec); err != nil {
-				fmt.Printf("Error parsing Instance spec: %v\n", err)
-				return
+				return fmt.Errorf("failed to parse Instance spec: %v", err)
 			}
 			if name == "" {
 				name = resourceSpec.Metadata.Name
@@ -127,13 +134,11 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 	if !isGenericFormat {
 		if strings.HasSuffix(filePath, ".json") {
 			if err := json.Unmarshal(data, &vmSpec); err != nil {
-				fmt.Printf("Error parsing JSON file: %v\n", err)
-				return
+				return fmt.Errorf("failed to parse JSON file: %v", err)
 			}
 		} else {
 			if err := yaml.Unmarshal(data, &vmSpec); err != nil {
-				fmt.Printf("Error parsing YAML file: %v\n", err)
-				return
+				return fmt.Errorf("failed to parse YAML file: %v", err)
 			}
 		}
 	}
@@ -143,8 +148,7 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 		vmSpec.Name = name
 	}
 	if vmSpec.Name == "" {
-		fmt.Println("Error: VM instance name is required")
-		return
+		return fmt.Errorf("VM instance name is required")
 	}
 
 	// 解析内存/磁盘大小
@@ -152,8 +156,7 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 	if vmSpec.MemorySize != "" {
 		parsed, err := utils.ParseMemorySize(vmSpec.MemorySize)
 		if err != nil {
-			fmt.Printf("Error parsing memory size: %v\n", err)
-			return
+			return fmt.Errorf("failed to parse memory size: %v", err)
 		}
 		memorySizeBytes = parsed
 	}
@@ -162,8 +165,7 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 	if vmSpec.RootDiskSize != "" {
 		parsed, err := utils.ParseMemorySize(vmSpec.RootDiskSize)
 		if err != nil {
-			fmt.Printf("Error parsing root disk size: %v\n", err)
-			return
+			return fmt.Errorf("failed to parse root disk size: %v", err)
 		}
 		rootDiskSizeBytes = &parsed
 	}
@@ -172,27 +174,23 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 	for _, size := range vmSpec.DataDiskSizes {
 		parsed, err := utils.ParseMemorySize(size)
 		if err != nil {
-			fmt.Printf("Error parsing data disk size: %v\n", err)
-			return
+			return fmt.Errorf("failed to parse data disk size: %v", err)
 		}
 		dataDiskSizesBytes = append(dataDiskSizesBytes, parsed)
 	}
 
 	cli := client.GetClient()
 	if cli == nil {
-		fmt.Println("Error: Client not initialized. Please run 'zstack-cli login' first.")
-		return
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
 	}
 
 	// Image
 	if vmSpec.Image == "" {
-		fmt.Println("Error: image is required")
-		return
+		return fmt.Errorf("image is required")
 	}
 	imageUuid, err := client.GetImageUUIDByName(cli, vmSpec.Image)
 	if err != nil {
-		fmt.Printf("Error finding image '%s': %v\n", vmSpec.Image, err)
-		return
+		return fmt.Errorf("failed to find image '%s': %v", vmSpec.Image, err)
 	}
 
 	// InstanceOffering
@@ -200,22 +198,19 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 	if vmSpec.InstanceOffering != "" {
 		instanceOfferingUuid, err = client.GetInstanceOfferingUUIDByName(cli, vmSpec.InstanceOffering)
 		if err != nil {
-			fmt.Printf("Error finding instance offering '%s': %v\n", vmSpec.InstanceOffering, err)
-			return
+			return fmt.Errorf("failed to find instance offering '%s': %v", vmSpec.InstanceOffering, err)
 		}
 	}
 
 	// L3Networks
 	if len(vmSpec.L3Networks) == 0 {
-		fmt.Println("Error: At least one L3 network is required")
-		return
+		return fmt.Errorf("at least one L3 network is required")
 	}
 	l3NetworkUuids := make([]string, 0, len(vmSpec.L3Networks))
 	for _, n := range vmSpec.L3Networks {
 		uuid, err := client.GetL3NetworkUUIDByName(cli, n)
 		if err != nil {
-			fmt.Printf("Error finding L3 network '%s': %v\n", n, err)
-			return
+			return fmt.Errorf("failed to find L3 network '%s': %v", n, err)
 		}
 		l3NetworkUuids = append(l3NetworkUuids, uuid)
 	}
@@ -225,8 +220,7 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 	if vmSpec.Zone != "" {
 		zoneUuid, err = client.GetZoneUUIDByName(cli, vmSpec.Zone)
 		if err != nil {
-			fmt.Printf("Error finding zone '%s': %v\n", vmSpec.Zone, err)
-			return
+			return fmt.Errorf("failed to find zone '%s': %v", vmSpec.Zone, err)
 		}
 	}
 
@@ -235,8 +229,7 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 	if vmSpec.Cluster != "" {
 		clusterUuid, err = client.GetClusterUUIDByName(cli, vmSpec.Cluster)
 		if err != nil {
-			fmt.Printf("Error finding cluster '%s': %v\n", vmSpec.Cluster, err)
-			return
+			return fmt.Errorf("failed to find cluster '%s': %v", vmSpec.Cluster, err)
 		}
 	}
 
@@ -245,8 +238,7 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 	if vmSpec.Host != "" {
 		hostUuid, err = client.GetHostUUIDByName(cli, vmSpec.Host)
 		if err != nil {
-			fmt.Printf("Error finding host '%s': %v\n", vmSpec.Host, err)
-			return
+			return fmt.Errorf("failed to find host '%s': %v", vmSpec.Host, err)
 		}
 	}
 
@@ -255,8 +247,7 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 	if vmSpec.PrimaryStorage != "" {
 		primaryStorageUuid, err = client.GetPrimaryStorageUUIDByName(cli, vmSpec.PrimaryStorage)
 		if err != nil {
-			fmt.Printf("Error finding primary storage '%s': %v\n", vmSpec.PrimaryStorage, err)
-			return
+			return fmt.Errorf("failed to find primary storage '%s': %v", vmSpec.PrimaryStorage, err)
 		}
 	}
 	var primaryStoragePtr *string
@@ -269,14 +260,22 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 	if vmSpec.DefaultL3Network != "" {
 		defaultL3NetworkUuid, err = client.GetL3NetworkUUIDByName(cli, vmSpec.DefaultL3Network)
 		if err != nil {
-			fmt.Printf("Error finding default L3 network '%s': %v\n", vmSpec.DefaultL3Network, err)
-			return
+			return fmt.Errorf("failed to find default L3 network '%s': %v", vmSpec.DefaultL3Network, err)
 		}
 	}
 
+	var userData []byte
+	if vmSpec.UserData != "" {
+		userData = []byte(vmSpec.UserData)
+	}
+	guestTags, err := guestSystemTags(vmSpec.SSHKeyFile, vmSpec.RootPassword, userData)
+	if err != nil {
+		return err
+	}
+
 	vmParam := param.CreateVmInstanceParam{
 		BaseParam: param.BaseParam{
-			SystemTags: vmSpec.SystemTags,
+			SystemTags: append(vmSpec.SystemTags, guestTags...),
 			UserTags:   vmSpec.UserTags,
 		},
 		Params: param.CreateVmInstanceDetailParam{
@@ -313,25 +312,30 @@ func createVmInstanceFromFile(cmd *cobra.Command, name string, filePath string)
 			format = "yaml"
 		}
 		utils.PrintDryRun(vmParam, format)
-		return
+		return nil
 	}
 
 	// ==== 调用 API ====
 	resp, err := cli.CreateVmInstance(vmParam)
 	if err != nil {
-		fmt.Printf("Error creating VM instance: %v\n", err)
-		return
+		return fmt.Errorf("failed to create VM instance: %v", err)
 	}
 
 	fmt.Printf("VM instance created successfully: %s\n", resp.UUID)
+	waiter := common.NewWaiter(cmd, os.Stdout)
+	resp, err = waitForCreatedVM(waiter, cli, resp, vmSpec.Strategy)
+	if err != nil {
+		return fmt.Errorf("failed to wait for VM instance: %w", err)
+	}
 	format, _ := cmd.Flags().GetString("output")
 	if format == "" {
 		format = "table"
 	}
 	utils.PrintOperationResult("instance", resp, format)
+	return nil
 }
 
-func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
+func createVmInstanceFromFlags(cmd *cobra.Command, name string) error {
 	imageStr, _ := cmd.Flags().GetString("image")
 	instanceOfferingStr, _ := cmd.Flags().GetString("instance-offering")
 	cpuNum, _ := cmd.Flags().GetInt64("cpu")
@@ -351,26 +355,25 @@ func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
 	strategy, _ := cmd.Flags().GetString("strategy")
 	systemTags, _ := cmd.Flags().GetStringSlice("system-tag")
 	userTags, _ := cmd.Flags().GetStringSlice("user-tag")
+	sshKeyFile, _ := cmd.Flags().GetString("ssh-key-file")
+	rootPassword, _ := cmd.Flags().GetString("root-password")
+	userDataFile, _ := cmd.Flags().GetString("user-data-file")
 
 	if imageStr == "" {
-		fmt.Println("Error: --image is required")
-		return
+		return fmt.Errorf("--image is required")
 	}
 	if len(l3NetworkStrs) == 0 {
-		fmt.Println("Error: At least one --l3-network is required")
-		return
+		return fmt.Errorf("at least one --l3-network is required")
 	}
 	if instanceOfferingStr == "" && (cpuNum == 0 || memorySize == "") {
-		fmt.Println("Error: Either --instance-offering or both --cpu and --memory must be specified")
-		return
+		return fmt.Errorf("either --instance-offering or both --cpu and --memory must be specified")
 	}
 
 	var memorySizeBytes int64
 	if memorySize != "" {
 		parsed, err := utils.ParseMemorySize(memorySize)
 		if err != nil {
-			fmt.Printf("Error parsing memory size: %v\n", err)
-			return
+			return fmt.Errorf("failed to parse memory size: %v", err)
 		}
 		memorySizeBytes = parsed
 	}
@@ -379,8 +382,7 @@ func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
 	if rootDiskSize != "" {
 		parsed, err := utils.ParseMemorySize(rootDiskSize)
 		if err != nil {
-			fmt.Printf("Error parsing root disk size: %v\n", err)
-			return
+			return fmt.Errorf("failed to parse root disk size: %v", err)
 		}
 		rootDiskSizeBytes = &parsed
 	}
@@ -390,8 +392,7 @@ func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
 		for _, size := range dataDiskSizes {
 			parsed, err := utils.ParseMemorySize(size)
 			if err != nil {
-				fmt.Printf("Error parsing data disk size: %v\n", err)
-				return
+				return fmt.Errorf("failed to parse data disk size: %v", err)
 			}
 			dataDiskSizesBytes = append(dataDiskSizesBytes, parsed)
 		}
@@ -399,22 +400,19 @@ func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
 
 	cli := client.GetClient()
 	if cli == nil {
-		fmt.Println("Error: Client not initialized. Please run 'zstack-cli login' first.")
-		return
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
 	}
 
 	imageUuidValue, err := client.GetImageUUIDByName(cli, imageStr)
 	if err != nil {
-		fmt.Printf("Error finding image '%s': %v\n", imageStr, err)
-		return
+		return fmt.Errorf("failed to find image '%s': %v", imageStr, err)
 	}
 
 	var instanceOfferingUuidValue string
 	if instanceOfferingStr != "" {
 		instanceOfferingUuidValue, err = client.GetInstanceOfferingUUIDByName(cli, instanceOfferingStr)
 		if err != nil {
-			fmt.Printf("Error finding instance offering '%s': %v\n", instanceOfferingStr, err)
-			return
+			return fmt.Errorf("failed to find instance offering '%s': %v", instanceOfferingStr, err)
 		}
 	}
 
@@ -422,8 +420,7 @@ func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
 	for _, nameOrUUID := range l3NetworkStrs {
 		uuid, err := client.GetL3NetworkUUIDByName(cli, nameOrUUID)
 		if err != nil {
-			fmt.Printf("Error finding L3 network '%s': %v\n", nameOrUUID, err)
-			return
+			return fmt.Errorf("failed to find L3 network '%s': %v", nameOrUUID, err)
 		}
 		l3NetworkUuidValues = append(l3NetworkUuidValues, uuid)
 	}
@@ -432,8 +429,7 @@ func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
 	if zoneStr != "" {
 		zoneUuidValue, err = client.GetZoneUUIDByName(cli, zoneStr)
 		if err != nil {
-			fmt.Printf("Error finding zone '%s': %v\n", zoneStr, err)
-			return
+			return fmt.Errorf("failed to find zone '%s': %v", zoneStr, err)
 		}
 	}
 
@@ -441,8 +437,7 @@ func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
 	if clusterStr != "" {
 		clusterUuidValue, err = client.GetClusterUUIDByName(cli, clusterStr)
 		if err != nil {
-			fmt.Printf("Error finding cluster '%s': %v\n", clusterStr, err)
-			return
+			return fmt.Errorf("failed to find cluster '%s': %v", clusterStr, err)
 		}
 	}
 
@@ -450,8 +445,7 @@ func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
 	if hostStr != "" {
 		hostUuidValue, err = client.GetHostUUIDByName(cli, hostStr)
 		if err != nil {
-			fmt.Printf("Error finding host '%s': %v\n", hostStr, err)
-			return
+			return fmt.Errorf("failed to find host '%s': %v", hostStr, err)
 		}
 	}
 
@@ -459,8 +453,7 @@ func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
 	if primaryStorageStr != "" {
 		primaryStorageUuidValue, err = client.GetPrimaryStorageUUIDByName(cli, primaryStorageStr)
 		if err != nil {
-			fmt.Printf("Error finding primary storage '%s': %v\n", primaryStorageStr, err)
-			return
+			return fmt.Errorf("failed to find primary storage '%s': %v", primaryStorageStr, err)
 		}
 	}
 	var primaryStoragePtr *string
@@ -472,14 +465,25 @@ func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
 	if defaultL3NetworkStr != "" {
 		defaultL3NetworkUuidValue, err = client.GetL3NetworkUUIDByName(cli, defaultL3NetworkStr)
 		if err != nil {
-			fmt.Printf("Error finding default L3 network '%s': %v\n", defaultL3NetworkStr, err)
-			return
+			return fmt.Errorf("failed to find default L3 network '%s': %v", defaultL3NetworkStr, err)
+		}
+	}
+
+	var userData []byte
+	if userDataFile != "" {
+		userData, err = os.ReadFile(userDataFile)
+		if err != nil {
+			return fmt.Errorf("failed to read user data file %s: %v", userDataFile, err)
 		}
 	}
+	guestTags, err := guestSystemTags(sshKeyFile, rootPassword, userData)
+	if err != nil {
+		return err
+	}
 
 	vmParam := param.CreateVmInstanceParam{
 		BaseParam: param.BaseParam{
-			SystemTags: systemTags,
+			SystemTags: append(systemTags, guestTags...),
 			UserTags:   userTags,
 		},
 		Params: param.CreateVmInstanceDetailParam{
@@ -511,22 +515,27 @@ func createVmInstanceFromFlags(cmd *cobra.Command, name string) {
 			format = "yaml"
 		}
 		utils.PrintDryRun(vmParam, format)
-		return
+		return nil
 	}
 
 	resp, err := cli.CreateVmInstance(vmParam)
 	if err != nil {
-		fmt.Printf("Error creating VM instance: %v\n", err)
-		return
+		return fmt.Errorf("failed to create VM instance: %v", err)
 	}
 
 	fmt.Printf("VM instance created successfully: %s\n", resp.UUID)
+	waiter := common.NewWaiter(cmd, os.Stdout)
+	resp, err = waitForCreatedVM(waiter, cli, resp, strategy)
+	if err != nil {
+		return fmt.Errorf("failed to wait for VM instance: %w", err)
+	}
 	format, _ := cmd.Flags().GetString("output")
 	if format == "" {
 		format = "table"
 	}
 
 	utils.PrintOperationResult("instance", resp, format)
+	return nil
 }
 
 func init() {
@@ -555,10 +564,55 @@ func init() {
 	instanceCmd.Flags().String("default-l3-network", "", "Default L3 network UUID")
 	instanceCmd.Flags().String("resource-uuid", "", "Resource UUID")
 	instanceCmd.Flags().String("strategy", "InstantStart", "VM creation strategy: InstantStart or CreateStopped")
+	common.AddWaitFlags(instanceCmd)
 
 	instanceCmd.Flags().StringSlice("system-tag", []string{}, "System tag(s)")
 	instanceCmd.Flags().StringSlice("user-tag", []string{}, "User tag(s)")
 
+	instanceCmd.Flags().String("ssh-key-file", "", "SSH public key file installed by cloud-init")
+	instanceCmd.Flags().String("root-password", "", "Root password set by cloud-init")
+	instanceCmd.Flags().String("user-data-file", "", "cloud-init user data file (e.g. a #cloud-config YAML)")
+
 	instanceCmd.Flags().Bool("dry-run", false, "Preview the API request without sending it")
 	instanceCmd.Flags().StringP("output", "o", "", "Output format: json, yaml, table, wide, or name")
 }
+
+// guestSystemTags turns the credential and user data options of an
+// instance into the system tags cloud-init reads on first boot.
+func guestSystemTags(sshKeyFile, rootPassword string, userData []byte) ([]string, error) {
+	var tags []string
+	if sshKeyFile != "" {
+		key, err := utils.ReadSSHPublicKey(sshKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, utils.SSHKeyTagPrefix+key)
+	}
+	if rootPassword != "" {
+		tags = append(tags, utils.RootPasswordTagPrefix+rootPassword)
+	}
+	if userData != nil {
+		if err := utils.ValidateUserData(userData); err != nil {
+			return nil, err
+		}
+		tags = append(tags, utils.UserDataSystemTag(userData))
+	}
+	return tags, nil
+}
+
+// waitForCreatedVM waits, when --wait is set, until a new VM is Running,
+// or Stopped for the CreateStopped strategy.
+func waitForCreatedVM(waiter *common.Waiter, cli *sdkClient.ZSClient, vm *view.VmInstanceInventoryView, strategy string) (*view.VmInstanceInventoryView, error) {
+	if !waiter.Enabled {
+		return vm, nil
+	}
+	state := types.VMStateRunning
+	if strategy == "CreateStopped" {
+		state = types.VMStateStopped
+	}
+	name := fmt.Sprintf("VM instance %s (%s)", vm.Name, vm.UUID)
+	if err := waiter.Until(name, common.PollVMState(cli, vm.UUID), []string{state}, []string{types.VMStateDestroyed}); err != nil {
+		return nil, err
+	}
+	return cli.GetVmInstance(vm.UUID)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package del
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+// deleteMode parses --mode.
+func deleteMode() (param.DeleteMode, error) {
+	switch strings.ToLower(modeFlag) {
+	case "", "permissive":
+		return param.DeleteModePermissive, nil
+	case "enforcing":
+		return param.DeleteModeEnforcing, nil
+	default:
+		return "", fmt.Errorf("invalid --mode %q, must be permissive or enforcing", modeFlag)
+	}
+}
+
+// printCascadePreview lists the resources that depend on the resource uuid
+// of type typeName and would be affected by deleting it.
+func printCascadePreview(w io.Writer, cli *sdkClient.ZSClient, typeName, label, uuid string) error {
+	fmt.Fprintf(w, "%s:\n", label)
+	deps := client.Dependencies[typeName]
+	if len(deps) == 0 {
+		fmt.Fprintln(w, "  no known dependent resources")
+		return nil
+	}
+	for _, dep := range deps {
+		dependents, err := client.FindDependents(cli, dep, uuid)
+		if err != nil {
+			return fmt.Errorf("query %s of %s failed: %s", dep.Label, label, err)
+		}
+		if len(dependents) == 0 {
+			fmt.Fprintf(w, "  %s: none\n", dep.Label)
+			continue
+		}
+		fmt.Fprintf(w, "  %s (%d), %s:\n", dep.Label, len(dependents), dep.Effect)
+		for _, inv := range dependents {
+			fmt.Fprintf(w, "    - %s\n", resourceLabel(inv))
+		}
+	}
+	return nil
+}
+
+// printModeNote explains what mode means for the dependents listed by
+// printCascadePreview.
+func printModeNote(w io.Writer, mode param.DeleteMode) {
+	if mode == param.DeleteModeEnforcing {
+		fmt.Fprintln(w, "Mode enforcing: dependents are cleaned up even if they object to the deletion.")
+		return
+	}
+	fmt.Fprintln(w, "Mode permissive: the deletion fails if dependent objects exist. Use --mode enforcing to delete regardless.")
+}
@@ -18,6 +18,7 @@ package del
 import (
 	"fmt"
 
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
 	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
@@ -27,6 +28,9 @@ var (
 	outputFlag  string
 	dryRunFlag  bool
 	verboseFlag bool
+
+	modeFlag           string
+	cascadePreviewFlag bool
 )
 
 var DeleteCmd = &cobra.Command{
@@ -46,12 +50,16 @@ func init() {
 
 	DeleteCmd.PersistentFlags().StringVarP(&fileFlag, "file", "f", "", "Filename, directory, or URL to files containing resource definitions to delete")
 	DeleteCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "Output format (json|yaml)")
-	DeleteCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Only print the object that would be deleted, without sending the request")
+	DeleteCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Print the API requests that would be sent, without sending them")
 	DeleteCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Enable verbose output")
+	common.AddConfirmFlags(DeleteCmd)
+	DeleteCmd.PersistentFlags().StringVar(&modeFlag, "mode", "permissive", "Delete mode: permissive (fail if dependent objects exist) or enforcing (delete regardless)")
+	DeleteCmd.PersistentFlags().BoolVar(&cascadePreviewFlag, "cascade-preview", false, "List the dependent resources affected by the deletion, without deleting anything")
 
 }
 
 func preRunCheckFile(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
 	if fileFlag != "" {
 		if dryRunFlag {
 			fmt.Println("Dry run mode: would process file", fileFlag)
@@ -16,14 +16,14 @@
 package del
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
+	"io"
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
-	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
 )
 
 var DeleteImagesCmd = &cobra.Command{
@@ -35,24 +35,30 @@ Examples:
   zstack-cli delete image my-image
 
   # Delete multiple images (same name matched)
-  zstack-cli delete image my-image`,
-	Args: cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) != 1 {
-			fmt.Println("Error: image name is required")
-			return
-		}
-		if err := deleteImage(cmd, args[0]); err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
+  zstack-cli delete image my-image
+
+  # Show the VM instances created from the image
+  zstack-cli delete image my-image --cascade-preview`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return deleteImage(cmd, args[0])
 	},
 }
 
 func init() {
 	DeleteCmd.AddCommand(DeleteImagesCmd)
+	common.AddBatchFlags(DeleteImagesCmd)
 }
 
 func deleteImage(cmd *cobra.Command, nameOrUUID string) error {
+	format := utils.ParseFormat(outputFlag)
+	w := utils.StatusWriter(format)
+
+	opts, err := common.BatchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	zsClient := client.GetClient()
 	if zsClient == nil {
 		return fmt.Errorf("not logged in. Please run 'zstack-cli login' first")
@@ -64,35 +70,77 @@ func deleteImage(cmd *cobra.Command, nameOrUUID string) error {
 	}
 
 	if len(images) == 0 {
-		fmt.Println("no Ready images found with name or UUID: %s", nameOrUUID)
+		fmt.Fprintf(w, "no Ready images found with name or UUID: %s\n", nameOrUUID)
 		return nil
 	}
 
-	fmt.Println("The following images will be deleted:")
-	for _, img := range images {
-		fmt.Printf("- %s (%s)\n", img.Name, img.UUID)
+	mode, err := deleteMode()
+	if err != nil {
+		return err
 	}
 
-	fmt.Print("Are you sure you want to delete these images? (yes/No): ")
-	reader := bufio.NewReader(os.Stdin)
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(strings.ToLower(input))
-	if input != "yes" && input != "y" {
-		fmt.Printf("Aborted. No images were deleted.\n")
+	if cascadePreviewFlag {
+		for _, img := range images {
+			if err := printCascadePreview(w, zsClient, "images", fmt.Sprintf("%s (%s)", img.Name, img.UUID), img.UUID); err != nil {
+				return err
+			}
+		}
+		printModeNote(w, mode)
 		return nil
 	}
 
+	fmt.Fprintln(w, "The following images will be deleted:")
+	for _, img := range images {
+		fmt.Fprintf(w, "  - %s (%s)\n", img.Name, img.UUID)
+	}
+
+	uuids := make([]string, 0, len(images))
 	for _, img := range images {
-		if dryRunFlag {
-			fmt.Printf("[Dry-run] Would delete image: %s (%s)\n", img.Name, img.UUID)
-			continue
+		uuids = append(uuids, img.UUID)
+	}
+	if err := common.CheckProtected(cmd, w, zsClient, "v1/images", uuids, "delete"); err != nil {
+		return err
+	}
+
+	if dryRunFlag {
+		calls := make([]utils.APICall, 0, len(images))
+		for _, img := range images {
+			calls = append(calls, utils.DeleteCall("v1/images", img.UUID, mode))
 		}
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFlag)
+		return nil
+	}
 
-		if err := zsClient.DeleteImage(img.UUID, param.DeleteModePermissive); err != nil {
-			fmt.Printf("Failed to delete image %s (%s): %s\n", img.Name, img.UUID, err)
+	names := make([]string, 0, len(images))
+	for _, img := range images {
+		names = append(names, img.Name)
+	}
+	if ok, err := common.Confirm(cmd, w, common.Confirmation{Action: "delete", Kind: "images", Count: len(images), Names: names, Destructive: true}); !ok {
+		return err
+	}
+
+	inventories := make([]map[string]interface{}, 0, len(images))
+	for _, img := range images {
+		inventories = append(inventories, imageInventory(img))
+	}
+	result := utils.NewResourceBatchResult("delete", "images", "deleted")
+	batchErr := common.RunBatchItems(opts, w, "delete image", inventories, resourceLabel, func(inv map[string]interface{}, out io.Writer) (*map[string]interface{}, error) {
+		if err := zsClient.DeleteImage(client.FieldValue(inv, "uuid"), mode); err != nil {
+			return nil, err
 		}
-		fmt.Printf("Deleted image: %s (%s)\n", img.Name, img.UUID)
+		fmt.Fprintf(out, "Deleted image: %s\n", resourceLabel(inv))
+		return &inv, nil
+	}, result)
 
+	if err := utils.PrintResourceSummary(result, format, nil); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
 	}
-	return nil
+	return batchErr
+}
+
+// imageInventory is the raw inventory form of img used by the batch
+// executor.
+func imageInventory(img view.ImageView) map[string]interface{} {
+	return map[string]interface{}{"name": img.Name, "uuid": img.UUID, "status": img.Status}
 }
@@ -16,15 +16,19 @@ package del
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
-	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
 
 	"github.com/spf13/cobra"
 )
 
 var DeleteInstancesCmd = &cobra.Command{
-	Use:   "instance [name-or-uuid]",
+	Use:   "instance [name-or-uuid...]",
 	Short: "Delete one or many VM instances",
 	Long: `Delete one or many ZStack VM instances.
 
@@ -33,58 +37,115 @@ Examples:
   zstack-cli delete instance my-vm
 
   # Delete multiple VM instances by name
-  zstack-cli delete instance my-vm 
+  zstack-cli delete instance my-vm-1 my-vm-2
 
-Note: This is a dangerous operation. You must confirm by typing 'yes'.`,
-	Args: cobra.ExactArgs(1),
+  # Delete every VM instance whose name contains "test-"
+  zstack-cli delete instance test- --match substring
+
+  # Delete every VM instance with the user tag env::test
+  zstack-cli delete instance --selector env=test
+
+  # Show the data volumes that would be detached
+  zstack-cli delete instance my-vm --cascade-preview
+
+Note: This is a dangerous operation. You must confirm by typing the name
+of a single resource, 'yes' for several, or the action and count (which
+stand in for the listed names) when more than 10 match. Resources with the
+user tag "protected" are refused unless --force is given.`,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		nameOrUUID := args[0]
-		return deleteVmInstance(cmd, nameOrUUID)
+		return deleteVmInstance(cmd, args)
 	},
 }
 
-func deleteVmInstance(cmd *cobra.Command, nameOrUUID string) error {
+func deleteVmInstance(cmd *cobra.Command, args []string) error {
+	format := utils.ParseFormat(outputFlag)
+	w := utils.StatusWriter(format)
+
+	opts, err := common.BatchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	mode, err := deleteMode()
+	if err != nil {
+		return err
+	}
+
 	cli := client.GetClient()
 	if cli == nil {
 		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
 	}
 
-	vms, err := client.GetReadyVMsByNameOrUUID(cli, nameOrUUID)
+	vms, err := common.SelectVMs(cmd, cli, args, types.IsVMActive)
 	if err != nil {
 		return fmt.Errorf("query VM instances failed: %s", err)
 	}
 
 	if len(vms) == 0 {
-		return fmt.Errorf("no Ready VM instances found with name or UUID: %s", nameOrUUID)
+		return fmt.Errorf("no Ready VM instances found")
 	}
 
-	fmt.Printf("The following VM instances will be deleted:\n")
+	if cascadePreviewFlag {
+		for _, vm := range vms {
+			if err := printCascadePreview(w, cli, "instances", fmt.Sprintf("%s (%s)", vm.Name, vm.UUID), vm.UUID); err != nil {
+				return err
+			}
+		}
+		printModeNote(w, mode)
+		return nil
+	}
+
+	fmt.Fprintf(w, "The following VM instances will be deleted:\n")
+	for _, vm := range vms {
+		fmt.Fprintf(w, "  - %s (%s)\n", vm.Name, vm.UUID)
+	}
+
+	uuids := make([]string, 0, len(vms))
 	for _, vm := range vms {
-		fmt.Printf("  - %s (%s)\n", vm.Name, vm.UUID)
+		uuids = append(uuids, vm.UUID)
+	}
+	if err := common.CheckProtected(cmd, w, cli, "v1/vm-instances", uuids, "delete"); err != nil {
+		return err
 	}
 
-	var input string
-	fmt.Print("Are you sure you want to delete the above VM instances? Type 'yes' to confirm: ")
-	fmt.Scanln(&input)
-	if input != "yes" && input != "y" {
-		fmt.Println("Aborted by user.")
+	if dryRunFlag {
+		calls := make([]utils.APICall, 0, len(vms))
+		for _, vm := range vms {
+			calls = append(calls, utils.DeleteCall("v1/vm-instances", vm.UUID, mode))
+		}
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFlag)
 		return nil
 	}
 
+	names := make([]string, 0, len(vms))
 	for _, vm := range vms {
-		err := cli.DestroyVmInstance(vm.UUID, param.DeleteModePermissive)
-		if err != nil {
-			fmt.Printf("Failed to delete VM instance %s (%s): %s\n", vm.Name, vm.UUID, err)
-			continue
+		names = append(names, vm.Name)
+	}
+	if ok, err := common.Confirm(cmd, w, common.Confirmation{Action: "delete", Kind: "VM instances", Count: len(vms), Names: names, Destructive: true}); !ok {
+		return err
+	}
+
+	result := utils.NewBatchResult("delete", "deleted")
+	batchErr := common.RunBatch(opts, w, result, vms, func(vm sdkView.VmInstanceInventoryView, out io.Writer) (*sdkView.VmInstanceInventoryView, error) {
+		if err := cli.DestroyVmInstance(vm.UUID, mode); err != nil {
+			return nil, err
 		}
-		fmt.Printf("Deleted VM instance: %s (%s)\n", vm.Name, vm.UUID)
+		fmt.Fprintf(out, "Deleted VM instance: %s (%s)\n", vm.Name, vm.UUID)
+		vm.State = types.VMStateDestroyed
+		return &vm, nil
+	})
 
+	if err := utils.PrintSummary(result, format, nil); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
 	}
 
-	return nil
+	return batchErr
 }
 
 func init() {
 	DeleteCmd.AddCommand(DeleteInstancesCmd)
+	common.AddBatchFlags(DeleteInstancesCmd)
+	common.AddVMSelectorFlags(DeleteInstancesCmd)
 
 }
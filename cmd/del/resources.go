@@ -0,0 +1,153 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package del
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// dedicatedDeleteCommands have their own delete commands with extra
+// matching and reporting.
+var dedicatedDeleteCommands = map[string]bool{"instances": true, "images": true}
+
+func init() {
+	for i := range client.ResourceTypes {
+		t := &client.ResourceTypes[i]
+		if t.DeletePath == "" || dedicatedDeleteCommands[t.Name] {
+			continue
+		}
+		DeleteCmd.AddCommand(newDeleteResourceCmd(t))
+	}
+}
+
+// newDeleteResourceCmd builds the delete subcommand of resource type t.
+func newDeleteResourceCmd(t *client.ResourceType) *cobra.Command {
+	singular := t.Aliases[0]
+	cmd := &cobra.Command{
+		Use:     singular + " <name-or-uuid>...",
+		Aliases: append([]string{t.Name}, t.Aliases[1:]...),
+		Short:   fmt.Sprintf("Delete one or many %s", t.Name),
+		Long: fmt.Sprintf(`Delete one or many %[1]s by exact name or UUID.
+
+Examples:
+  zstack-cli delete %[2]s my-%[2]s
+  zstack-cli delete %[2]s <uuid-1> <uuid-2> --parallel 4
+  zstack-cli delete %[2]s my-%[2]s --cascade-preview
+
+Note: This is a dangerous operation. You must confirm by typing the name
+of a single resource, 'yes' for several, or the action and count (which
+stand in for the listed names) when more than 10 match. Resources with the
+user tag "protected" are refused unless --force is given.`, t.Name, singular),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deleteResources(cmd, t, args)
+		},
+	}
+	common.AddBatchFlags(cmd)
+	return cmd
+}
+
+func deleteResources(cmd *cobra.Command, t *client.ResourceType, args []string) error {
+	format := utils.ParseFormat(outputFlag)
+	w := utils.StatusWriter(format)
+
+	opts, err := common.BatchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	mode, err := deleteMode()
+	if err != nil {
+		return err
+	}
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	resources, err := client.FindResourcesByNamesOrUUIDs(cli, t.Path, args, nil)
+	if err != nil {
+		return fmt.Errorf("query %s failed: %s", t.Name, err)
+	}
+
+	if cascadePreviewFlag {
+		for _, inv := range resources {
+			if err := printCascadePreview(w, cli, t.Name, resourceLabel(inv), client.FieldValue(inv, "uuid")); err != nil {
+				return err
+			}
+		}
+		printModeNote(w, mode)
+		return nil
+	}
+
+	fmt.Fprintf(w, "The following %s will be deleted:\n", t.Name)
+	for _, inv := range resources {
+		fmt.Fprintf(w, "  - %s\n", resourceLabel(inv))
+	}
+
+	// Tags cannot carry tags themselves, so they are never protected.
+	if t.Name != "tags" {
+		uuids := make([]string, 0, len(resources))
+		for _, inv := range resources {
+			uuids = append(uuids, client.FieldValue(inv, "uuid"))
+		}
+		if err := common.CheckProtected(cmd, w, cli, t.Path, uuids, "delete"); err != nil {
+			return err
+		}
+	}
+
+	if dryRunFlag {
+		calls := make([]utils.APICall, 0, len(resources))
+		for _, inv := range resources {
+			calls = append(calls, utils.DeleteCall(t.DeletePath, client.FieldValue(inv, "uuid"), mode))
+		}
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFlag)
+		return nil
+	}
+
+	names := make([]string, 0, len(resources))
+	for _, inv := range resources {
+		names = append(names, client.FieldValue(inv, "name"))
+	}
+	if ok, err := common.Confirm(cmd, w, common.Confirmation{Action: "delete", Kind: t.Name, Count: len(resources), Names: names, Destructive: true}); !ok {
+		return err
+	}
+
+	result := utils.NewResourceBatchResult("delete", t.Name, "deleted")
+	batchErr := common.RunBatchItems(opts, w, "delete", resources, resourceLabel, func(inv map[string]interface{}, out io.Writer) (*map[string]interface{}, error) {
+		if err := client.DeleteResource(cli, t, client.FieldValue(inv, "uuid"), mode); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(out, "Deleted %s: %s\n", t.Aliases[0], resourceLabel(inv))
+		return &inv, nil
+	}, result)
+
+	if err := utils.PrintResourceSummary(result, format, nil); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return batchErr
+}
+
+// resourceLabel names a raw inventory in messages.
+func resourceLabel(inv map[string]interface{}) string {
+	return fmt.Sprintf("%s (%s)", client.FieldValue(inv, "name"), client.FieldValue(inv, "uuid"))
+}
@@ -18,6 +18,7 @@ package expunge
 import (
 	"fmt"
 
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
 	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
@@ -47,11 +48,13 @@ func init() {
 
 	ExpungeCmd.PersistentFlags().StringVarP(&fileFlag, "file", "f", "", "Filename, directory, or URL to files containing resource definitions to delete")
 	ExpungeCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "Output format (json|yaml)")
-	ExpungeCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Only print the object that would be deleted, without sending the request")
+	ExpungeCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Print the API requests that would be sent, without sending them")
 	ExpungeCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Enable verbose output")
+	common.AddConfirmFlags(ExpungeCmd)
 }
 
 func preRunCheckFile(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
 	if fileFlag != "" {
 		if dryRunFlag {
 			fmt.Println("Dry run mode: would process file", fileFlag)
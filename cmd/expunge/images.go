@@ -16,12 +16,12 @@
 package expunge
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
+	"io"
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -35,23 +35,26 @@ Examples:
 
   # Delete multiple images (same name matched)
   zstack-cli expunge image my-image`,
-	Args: cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) != 1 {
-			fmt.Println("Error: image name is required")
-			return
-		}
-		if err := expungeImage(cmd, args[0]); err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return expungeImage(cmd, args[0])
 	},
 }
 
 func init() {
 	ExpungeCmd.AddCommand(ExpungeImagesCmd)
+	common.AddBatchFlags(ExpungeImagesCmd)
 }
 
 func expungeImage(cmd *cobra.Command, nameOrUUID string) error {
+	format := utils.ParseFormat(outputFlag)
+	w := utils.StatusWriter(format)
+
+	opts, err := common.BatchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	zsClient := client.GetClient()
 	if zsClient == nil {
 		return fmt.Errorf("not logged in. Please run 'zstack-cli login' first")
@@ -63,35 +66,59 @@ func expungeImage(cmd *cobra.Command, nameOrUUID string) error {
 	}
 
 	if len(images) == 0 {
-		fmt.Println("no Ready images found with name or UUID: %s", nameOrUUID)
+		fmt.Fprintf(w, "no deleted images found with name or UUID: %s\n", nameOrUUID)
 		return nil
 	}
 
-	fmt.Println("The following images will be deleted:")
+	fmt.Fprintln(w, "The following images will be expunged:")
 	for _, img := range images {
-		fmt.Printf("- %s (%s)\n", img.Name, img.UUID)
+		fmt.Fprintf(w, "  - %s (%s)\n", img.Name, img.UUID)
 	}
 
-	fmt.Print("Are you sure you want to delete these images? (yes/no): ")
-	reader := bufio.NewReader(os.Stdin)
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(strings.ToLower(input))
-	if input != "yes" {
-		fmt.Printf("Aborted. No images were deleted.\n")
+	uuids := make([]string, 0, len(images))
+	for _, img := range images {
+		uuids = append(uuids, img.UUID)
+	}
+	if err := common.CheckProtected(cmd, w, zsClient, "v1/images", uuids, "expunge"); err != nil {
+		return err
+	}
+
+	if dryRunFlag {
+		calls := make([]utils.APICall, 0, len(images))
+		for _, img := range images {
+			calls = append(calls, utils.ActionCall("v1/images", img.UUID, utils.EmptyAction("expungeImage")))
+		}
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFlag)
 		return nil
 	}
 
+	names := make([]string, 0, len(images))
 	for _, img := range images {
-		if dryRunFlag {
-			fmt.Printf("[Dry-run] Would delete image: %s (%s)\n", img.Name, img.UUID)
-			continue
-		}
+		names = append(names, img.Name)
+	}
+	if ok, err := common.Confirm(cmd, w, common.Confirmation{Action: "expunge", Kind: "images", Count: len(images), Names: names, Destructive: true}); !ok {
+		return err
+	}
 
-		if err := zsClient.ExpungeImage(img.UUID); err != nil {
-			fmt.Printf("Failed to delete image %s (%s): %s\n", img.Name, img.UUID, err)
+	label := func(inv map[string]interface{}) string {
+		return fmt.Sprintf("%s (%s)", client.FieldValue(inv, "name"), client.FieldValue(inv, "uuid"))
+	}
+	inventories := make([]map[string]interface{}, 0, len(images))
+	for _, img := range images {
+		inventories = append(inventories, map[string]interface{}{"name": img.Name, "uuid": img.UUID, "status": img.Status})
+	}
+	result := utils.NewResourceBatchResult("expunge", "images", "expunged")
+	batchErr := common.RunBatchItems(opts, w, "expunge image", inventories, label, func(inv map[string]interface{}, out io.Writer) (*map[string]interface{}, error) {
+		if err := zsClient.ExpungeImage(client.FieldValue(inv, "uuid")); err != nil {
+			return nil, err
 		}
-		fmt.Printf("Deleted image: %s (%s)\n", img.Name, img.UUID)
+		fmt.Fprintf(out, "Expunged image: %s\n", label(inv))
+		return &inv, nil
+	}, result)
 
+	if err := utils.PrintResourceSummary(result, format, nil); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
 	}
-	return nil
+	return batchErr
 }
@@ -16,14 +16,19 @@ package expunge
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
 
 	"github.com/spf13/cobra"
 )
 
 var ExpungeInstancesCmd = &cobra.Command{
-	Use:   "instance [name-or-uuid]",
+	Use:   "instance [name-or-uuid...]",
 	Short: "Expunge one or many VM instances",
 	Long: `Expunge one or many ZStack VM instances.
 
@@ -31,59 +36,100 @@ Examples:
   # Delete a single VM instance by name or UUID
   zstack-cli expunge instance my-vm
 
-  # Delete multiple VM instances by name
-  zstack-cli expunge instance my-vm 
+  # Expunge multiple VM instances by name
+  zstack-cli expunge instance my-vm-1 my-vm-2
 
-Note: This is a dangerous operation. You must confirm by typing 'yes'.`,
-	Args: cobra.ExactArgs(1),
+  # Expunge every VM instance whose name contains "test-"
+  zstack-cli expunge instance test- --match substring
+
+  # Expunge the VM instances listed in a file
+  zstack-cli expunge instance --from-file uuids.txt
+
+Note: This is a dangerous operation. You must confirm by typing the name
+of a single resource, 'yes' for several, or the action and count (which
+stand in for the listed names) when more than 10 match. Resources with the
+user tag "protected" are refused unless --force is given.`,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		nameOrUUID := args[0]
-		return deleteVmInstance(cmd, nameOrUUID)
+		return deleteVmInstance(cmd, args)
 	},
 }
 
-func deleteVmInstance(cmd *cobra.Command, nameOrUUID string) error {
+func deleteVmInstance(cmd *cobra.Command, args []string) error {
+	format := utils.ParseFormat(outputFlag)
+	w := utils.StatusWriter(format)
+
+	opts, err := common.BatchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	cli := client.GetClient()
 	if cli == nil {
 		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
 	}
 
-	vms, err := client.GetDestroyedVMsByNameOrUUID(cli, nameOrUUID)
+	vms, err := common.SelectVMs(cmd, cli, args, func(state string) bool {
+		return state == types.VMStateDestroyed
+	})
 	if err != nil {
 		return fmt.Errorf("query VM instances failed: %s", err)
 	}
 
 	if len(vms) == 0 {
-		return fmt.Errorf("no Destroyed VM instances found with name or UUID: %s", nameOrUUID)
+		return fmt.Errorf("no Destroyed VM instances found")
+	}
+
+	fmt.Fprintf(w, "The following VM instances will be Expunge:\n")
+	for _, vm := range vms {
+		fmt.Fprintf(w, "  - %s (%s)\n", vm.Name, vm.UUID)
 	}
 
-	fmt.Printf("The following VM instances will be Expunge:\n")
+	uuids := make([]string, 0, len(vms))
 	for _, vm := range vms {
-		fmt.Printf("  - %s (%s)\n", vm.Name, vm.UUID)
+		uuids = append(uuids, vm.UUID)
+	}
+	if err := common.CheckProtected(cmd, w, cli, "v1/vm-instances", uuids, "expunge"); err != nil {
+		return err
 	}
 
-	var input string
-	fmt.Print("Are you sure you want to Expunge the above VM instances? Type 'yes' to confirm: ")
-	fmt.Scanln(&input)
-	if input != "yes" {
-		fmt.Println("Aborted by user.")
+	if dryRunFlag {
+		calls := make([]utils.APICall, 0, len(vms))
+		for _, vm := range vms {
+			calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, utils.EmptyAction("expungeVmInstance")))
+		}
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFlag)
 		return nil
 	}
 
+	names := make([]string, 0, len(vms))
 	for _, vm := range vms {
-		err := cli.ExpungeVmInstance(vm.UUID)
-		if err != nil {
-			fmt.Printf("Failed to Expunge VM instance %s (%s): %s\n", vm.Name, vm.UUID, err)
-			continue
+		names = append(names, vm.Name)
+	}
+	if ok, err := common.Confirm(cmd, w, common.Confirmation{Action: "expunge", Kind: "VM instances", Count: len(vms), Names: names, Destructive: true}); !ok {
+		return err
+	}
+
+	result := utils.NewBatchResult("expunge", "expunged")
+	batchErr := common.RunBatch(opts, w, result, vms, func(vm sdkView.VmInstanceInventoryView, out io.Writer) (*sdkView.VmInstanceInventoryView, error) {
+		if err := cli.ExpungeVmInstance(vm.UUID); err != nil {
+			return nil, err
 		}
-		fmt.Printf("Expunged VM instance: %s (%s)\n", vm.Name, vm.UUID)
+		fmt.Fprintf(out, "Expunged VM instance: %s (%s)\n", vm.Name, vm.UUID)
+		return &vm, nil
+	})
 
+	if err := utils.PrintSummary(result, format, nil); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
 	}
 
-	return nil
+	return batchErr
 }
 
 func init() {
 	ExpungeCmd.AddCommand(ExpungeInstancesCmd)
+	common.AddBatchFlags(ExpungeInstancesCmd)
+	common.AddVMSelectorFlags(ExpungeInstancesCmd)
 
 }
@@ -0,0 +1,124 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expunge
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// volumeStatusDeleted is the status of a deleted data volume that has not
+// been expunged yet.
+const volumeStatusDeleted = "Deleted"
+
+var ExpungeVolumesCmd = &cobra.Command{
+	Use:     "volume <name-or-uuid>...",
+	Aliases: []string{"volumes", "disk", "disks"},
+	Short:   "Expunge one or many deleted data volumes",
+	Long: `Expunge deleted data volumes by exact name or UUID. Expunged volumes
+cannot be recovered.
+
+Examples:
+  zstack-cli expunge volume data-01
+  zstack-cli expunge volume <uuid-1> <uuid-2>
+
+Note: This is a dangerous operation. You must confirm by typing the name
+of a single volume, 'yes' for several, or the action and count (which
+stand in for the listed names) when more than 10 match.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return expungeVolumes(cmd, args)
+	},
+}
+
+func init() {
+	ExpungeCmd.AddCommand(ExpungeVolumesCmd)
+	common.AddBatchFlags(ExpungeVolumesCmd)
+}
+
+func expungeVolumes(cmd *cobra.Command, args []string) error {
+	format := utils.ParseFormat(outputFlag)
+	w := utils.StatusWriter(format)
+
+	opts, err := common.BatchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	volumes, err := client.FindResourcesByNamesOrUUIDs(cli, "v1/volumes", args, func(inv map[string]interface{}) bool {
+		return client.FieldValue(inv, "status") == volumeStatusDeleted
+	})
+	if err != nil {
+		return fmt.Errorf("query deleted volumes failed: %s", err)
+	}
+
+	fmt.Fprintf(w, "The following volumes will be expunged:\n")
+	for _, inv := range volumes {
+		fmt.Fprintf(w, "  - %s (%s)\n", client.FieldValue(inv, "name"), client.FieldValue(inv, "uuid"))
+	}
+
+	uuids := make([]string, 0, len(volumes))
+	for _, inv := range volumes {
+		uuids = append(uuids, client.FieldValue(inv, "uuid"))
+	}
+	if err := common.CheckProtected(cmd, w, cli, "v1/volumes", uuids, "expunge"); err != nil {
+		return err
+	}
+
+	if dryRunFlag {
+		calls := make([]utils.APICall, 0, len(volumes))
+		for _, inv := range volumes {
+			calls = append(calls, utils.ActionCall("v1/volumes", client.FieldValue(inv, "uuid"), utils.EmptyAction("expungeDataVolume")))
+		}
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFlag)
+		return nil
+	}
+
+	names := make([]string, 0, len(volumes))
+	for _, inv := range volumes {
+		names = append(names, client.FieldValue(inv, "name"))
+	}
+	if ok, err := common.Confirm(cmd, w, common.Confirmation{Action: "expunge", Kind: "volumes", Count: len(volumes), Names: names, Destructive: true}); !ok {
+		return err
+	}
+
+	label := func(inv map[string]interface{}) string {
+		return fmt.Sprintf("%s (%s)", client.FieldValue(inv, "name"), client.FieldValue(inv, "uuid"))
+	}
+	result := utils.NewResourceBatchResult("expunge", "volumes", "expunged")
+	batchErr := common.RunBatchItems(opts, w, "expunge", volumes, label, func(inv map[string]interface{}, out io.Writer) (*map[string]interface{}, error) {
+		if err := cli.ExpungeDataVolume(client.FieldValue(inv, "uuid")); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(out, "Expunged volume: %s\n", label(inv))
+		return &inv, nil
+	}, result)
+
+	if err := utils.PrintResourceSummary(result, format, nil); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return batchErr
+}
@@ -26,9 +26,11 @@ import (
 
 type FormattedCdRom struct {
 	UUID           string  `json:"uuid" yaml:"uuid" header:"UUID"`
-	VMInstanceUUID string  `json:"vmInstanceUuid" yaml:"vmInstanceUuid" header:"VM UUID"`
+	VMInstanceName string  `json:"vmInstanceName,omitempty" yaml:"vmInstanceName,omitempty" header:"VM"`
+	VMInstanceUUID string  `json:"vmInstanceUuid" yaml:"vmInstanceUuid" header:"VM UUID" resolve:"VMInstanceName"`
 	DeviceID       float64 `json:"deviceId" yaml:"deviceId" header:"DEVICE"`
-	IsoUUID        string  `json:"isoUuid" yaml:"isoUuid" header:"ISO UUID"`
+	IsoName        string  `json:"isoName,omitempty" yaml:"isoName,omitempty" header:"ISO"`
+	IsoUUID        string  `json:"isoUuid" yaml:"isoUuid" header:"ISO UUID" resolve:"IsoName"`
 	IsoInstallPath string  `json:"isoInstallPath" yaml:"isoInstallPath" header:"ISO PATH"`
 	Name           string  `json:"name" yaml:"name" header:"NAME"`
 	Description    string  `json:"description" yaml:"description" header:"DESCRIPTION"`
@@ -41,7 +43,7 @@ var cdromsCmd = &cobra.Command{
 	Short:   "List VM CD-ROM devices",
 	Long:    `List all VM CD-ROM devices in the ZStack cloud platform.`,
 	Args:    cobra.MaximumNArgs(0),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -55,6 +57,10 @@ var cdromsCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/vm-instances/cdroms") {
+			return
+		}
+
 		usePagination, _ := cobraCmd.Flags().GetBool("pagination")
 		var cdroms []view.VMCDRomView
 		var total int
@@ -72,6 +78,7 @@ var cdromsCmd = &cobra.Command{
 				fmt.Printf("Error querying CD-ROMs: %s\n", err)
 				return
 			}
+			common.PrintReplyCount(cobraCmd, queryParam)
 		}
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
@@ -93,16 +100,32 @@ var cdromsCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		names := common.NameResolverFromFlags(cobraCmd, zsClient)
+		for _, r := range formattedResults {
+			names.Add(client.KindVmInstance, r.VMInstanceUUID)
+			names.Add(client.KindImage, r.IsoUUID)
+		}
+		if err := names.Fetch(); err != nil {
+			fmt.Printf("Error resolving names: %s\n", err)
+			return
+		}
+		for i := range formattedResults {
+			r := &formattedResults[i]
+			r.VMInstanceName = names.Name(r.VMInstanceUUID)
+			r.IsoName = names.Name(r.IsoUUID)
+		}
+
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(cdromsCmd)
 	common.AddQueryFlags(cdromsCmd)
+	common.AddResolveNamesFlag(cdromsCmd)
 	cdromsCmd.Flags().Bool("pagination", false, "Use pagination when querying CD-ROMs")
 }
@@ -37,7 +37,7 @@ Examples:
   zstack-cli get clusters --output json
   zstack-cli get clusters --output yaml
   zstack-cli get clusters --output text`,
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -51,6 +51,10 @@ Examples:
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/clusters") {
+			return
+		}
+
 		clusters, err := zsClient.QueryCluster(*queryParam)
 		if err != nil {
 			fmt.Printf("Query failed: %s\n", err)
@@ -68,6 +72,7 @@ Examples:
 
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -78,12 +83,12 @@ Examples:
 			return
 		}
 
-		err = utils.PrintWithFields(clusters, format, fields)
+		err = common.PrintResult(cobraCmd, clusters, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
@@ -36,7 +36,7 @@ var diskOfferingsCmd = &cobra.Command{
 	Use:   "disk-offerings [name]",
 	Short: "List disk offerings",
 	Long:  `List all disk offerings in the ZStack cloud platform.`,
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -50,11 +50,16 @@ var diskOfferingsCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/disk-offerings") {
+			return
+		}
+
 		diskOfferings, err := zsClient.QueryDiskOffering(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying disk offerings: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -73,12 +78,12 @@ var diskOfferingsCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
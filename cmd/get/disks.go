@@ -29,11 +29,15 @@ type FormattedVolume struct {
 	Name               string  `json:"name" yaml:"name" header:"NAME"`
 	UUID               string  `json:"uuid" yaml:"uuid" header:"UUID"`
 	Description        string  `json:"description" yaml:"description" header:"DESCRIPTION"`
-	PrimaryStorageUUID string  `json:"primaryStorageUuid" yaml:"primaryStorageUuid" header:"PRIMARY STORAGE UUID"`
-	VMInstanceUUID     string  `json:"vmInstanceUuid" yaml:"vmInstanceUuid" header:"VM INSTANCE UUID"`
+	PrimaryStorageName string  `json:"primaryStorageName,omitempty" yaml:"primaryStorageName,omitempty" header:"PRIMARY STORAGE"`
+	PrimaryStorageUUID string  `json:"primaryStorageUuid" yaml:"primaryStorageUuid" header:"PRIMARY STORAGE UUID" resolve:"PrimaryStorageName"`
+	VMInstanceName     string  `json:"vmInstanceName,omitempty" yaml:"vmInstanceName,omitempty" header:"VM INSTANCE"`
+	VMInstanceUUID     string  `json:"vmInstanceUuid" yaml:"vmInstanceUuid" header:"VM INSTANCE UUID" resolve:"VMInstanceName"`
 	LastVmInstanceUuid string  `json:"lastVmInstanceUuid" yaml:"lastVmInstanceUuid" header:"LAST VM INSTANCE UUID"`
-	DiskOfferingUUID   string  `json:"diskOfferingUuid" yaml:"diskOfferingUuid" header:"DISK OFFERING UUID"`
-	RootImageUUID      string  `json:"rootImageUuid" yaml:"rootImageUuid" header:"ROOT IMAGE UUID"`
+	DiskOfferingName   string  `json:"diskOfferingName,omitempty" yaml:"diskOfferingName,omitempty" header:"DISK OFFERING"`
+	DiskOfferingUUID   string  `json:"diskOfferingUuid" yaml:"diskOfferingUuid" header:"DISK OFFERING UUID" resolve:"DiskOfferingName"`
+	RootImageName      string  `json:"rootImageName,omitempty" yaml:"rootImageName,omitempty" header:"ROOT IMAGE"`
+	RootImageUUID      string  `json:"rootImageUuid" yaml:"rootImageUuid" header:"ROOT IMAGE UUID" resolve:"RootImageName"`
 	InstallPath        string  `json:"installPath" yaml:"installPath" header:"INSTALL PATH"`
 	Type               string  `json:"type" yaml:"type" header:"TYPE"`
 	Format             string  `json:"format" yaml:"format" header:"FORMAT"`
@@ -52,7 +56,7 @@ var volumesCmd = &cobra.Command{
 	Short: "List disks",
 	Long:  `List all volumes (disks) in the ZStack cloud platform.`,
 	Args:  cobra.MaximumNArgs(1),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -66,6 +70,21 @@ var volumesCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/volumes") {
+			return
+		}
+
+		names := common.NameResolverFromFlags(cobraCmd, zsClient)
+		if common.AllPages(cobraCmd) {
+			err = common.StreamAllPages(cobraCmd, queryParam, zsClient.QueryVolume, func(volumes []view.VolumeView) (interface{}, error) {
+				return formatVolumes(volumes, names)
+			})
+			if err != nil {
+				fmt.Printf("Error querying volumes: %s\n", err)
+			}
+			return
+		}
+
 		usePagination, _ := cobraCmd.Flags().GetBool("pagination")
 		var volumes []view.VolumeView
 		var total int
@@ -85,6 +104,7 @@ var volumesCmd = &cobra.Command{
 				fmt.Printf("Error querying volumes: %s\n", err)
 				return
 			}
+			common.PrintReplyCount(cobraCmd, queryParam)
 		}
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
@@ -92,53 +112,89 @@ var volumesCmd = &cobra.Command{
 
 		fields, _ := cobraCmd.Flags().GetStringSlice("fields")
 
-		var formattedResults []FormattedVolume
-		for _, volume := range volumes {
-			attached := "No"
-			if volume.VMInstanceUUID != "" {
-				attached = "Yes"
-			}
-
-			lastDetachDate := ""
-			var zeroTime time.Time
-			if volume.LastDetachDate != zeroTime {
-				lastDetachDate = volume.LastDetachDate.Format("2006-01-02 15:04:05")
-			}
-
-			formatted := FormattedVolume{
-				Name:               volume.Name,
-				UUID:               volume.UUID,
-				Description:        volume.Description,
-				PrimaryStorageUUID: volume.PrimaryStorageUUID,
-				VMInstanceUUID:     volume.VMInstanceUUID,
-				LastVmInstanceUuid: volume.LastVmInstanceUuid,
-				DiskOfferingUUID:   volume.DiskOfferingUUID,
-				RootImageUUID:      volume.RootImageUUID,
-				InstallPath:        volume.InstallPath,
-				Type:               volume.Type,
-				Format:             volume.Format,
-				Size:               utils.FormatDiskSize(int64(volume.Size)),
-				ActualSize:         utils.FormatDiskSize(int64(volume.ActualSize)),
-				DeviceID:           volume.DeviceID,
-				State:              volume.State,
-				Status:             volume.Status,
-				IsShareable:        volume.IsShareable,
-				LastDetachDate:     lastDetachDate,
-				Attached:           attached,
-			}
-			formattedResults = append(formattedResults, formatted)
+		formattedResults, err := formatVolumes(volumes, names)
+		if err != nil {
+			fmt.Printf("Error resolving names: %s\n", err)
+			return
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(volumesCmd)
 	common.AddQueryFlags(volumesCmd)
+	common.AddFilterFlags(volumesCmd,
+		common.FilterFlag{Name: "status", Field: "status", Type: common.FilterEnum, Usage: "volume status", Values: []string{"Ready", "NotInstantiated", "Creating", "Deleted"}},
+		common.FilterFlag{Name: "type", Field: "type", Type: common.FilterEnum, Usage: "volume type", Values: []string{"Root", "Data"}},
+		common.FilterFlag{Name: "size", Field: "size", Type: common.FilterSize, Usage: "volume size"},
+		common.FilterFlag{Name: "primary-storage", Field: "primaryStorageUuid", Type: common.FilterLookup, Usage: "primary storage", Lookup: client.GetPrimaryStorageUUIDByName},
+		common.CreatedFilter(),
+	)
+	common.AddPagingFlags(volumesCmd)
+	common.AddResolveNamesFlag(volumesCmd)
 	volumesCmd.Flags().Bool("pagination", false, "Use pagination when querying volumes")
 }
+
+func formatVolumes(volumes []view.VolumeView, names *client.NameResolver) ([]FormattedVolume, error) {
+	var formattedResults []FormattedVolume
+	for _, volume := range volumes {
+		attached := "No"
+		if volume.VMInstanceUUID != "" {
+			attached = "Yes"
+		}
+
+		lastDetachDate := ""
+		var zeroTime time.Time
+		if volume.LastDetachDate != zeroTime {
+			lastDetachDate = volume.LastDetachDate.Format("2006-01-02 15:04:05")
+		}
+
+		formatted := FormattedVolume{
+			Name:               volume.Name,
+			UUID:               volume.UUID,
+			Description:        volume.Description,
+			PrimaryStorageUUID: volume.PrimaryStorageUUID,
+			VMInstanceUUID:     volume.VMInstanceUUID,
+			LastVmInstanceUuid: volume.LastVmInstanceUuid,
+			DiskOfferingUUID:   volume.DiskOfferingUUID,
+			RootImageUUID:      volume.RootImageUUID,
+			InstallPath:        volume.InstallPath,
+			Type:               volume.Type,
+			Format:             volume.Format,
+			Size:               utils.FormatDiskSize(int64(volume.Size)),
+			ActualSize:         utils.FormatDiskSize(int64(volume.ActualSize)),
+			DeviceID:           volume.DeviceID,
+			State:              volume.State,
+			Status:             volume.Status,
+			IsShareable:        volume.IsShareable,
+			LastDetachDate:     lastDetachDate,
+			Attached:           attached,
+		}
+		formattedResults = append(formattedResults, formatted)
+	}
+
+	for _, r := range formattedResults {
+		names.Add(client.KindPrimaryStorage, r.PrimaryStorageUUID)
+		names.Add(client.KindVmInstance, r.VMInstanceUUID)
+		names.Add(client.KindDiskOffering, r.DiskOfferingUUID)
+		names.Add(client.KindImage, r.RootImageUUID)
+	}
+	if err := names.Fetch(); err != nil {
+		return nil, err
+	}
+	for i := range formattedResults {
+		r := &formattedResults[i]
+		r.PrimaryStorageName = names.Name(r.PrimaryStorageUUID)
+		r.VMInstanceName = names.Name(r.VMInstanceUUID)
+		r.DiskOfferingName = names.Name(r.DiskOfferingUUID)
+		r.RootImageName = names.Name(r.RootImageUUID)
+	}
+
+	return formattedResults, nil
+}
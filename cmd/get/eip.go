@@ -38,7 +38,7 @@ var eipsCmd = &cobra.Command{
 	Use:   "eips [name]",
 	Short: "List elastic IPs",
 	Long:  `List all elastic IPs (EIPs) in the ZStack cloud platform.`,
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -52,11 +52,16 @@ var eipsCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/eips") {
+			return
+		}
+
 		eips, err := zsClient.QueryEip(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying elastic IPs: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		if len(eips) == 0 {
 			fmt.Println("No elastic IPs found.")
@@ -82,12 +87,12 @@ var eipsCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
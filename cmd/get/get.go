@@ -16,11 +16,19 @@
 package get
 
 import (
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
 	"github.com/spf13/cobra"
 )
 
 var GetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Display one or many resources",
-	Long:  `Display one or many ZStack resources.`,
+	Long: `Display one or many ZStack resources.
+
+The instances, disks, images, snapshots and nics commands also accept --all
+to fetch large inventories page by page.`,
+}
+
+func init() {
+	common.AddWatchFlags(GetCmd)
 }
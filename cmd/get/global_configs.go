@@ -37,7 +37,7 @@ var globalConfigsCmd = &cobra.Command{
 	Short:   "List global configurations",
 	Long:    `List all global configurations in the ZStack cloud platform.`,
 	Args:    cobra.MaximumNArgs(1),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -57,11 +57,16 @@ var globalConfigsCmd = &cobra.Command{
 			queryParam.AddQ(fmt.Sprintf("category=%s", category))
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/global-configurations") {
+			return
+		}
+
 		configs, err := zsClient.QueryGlobalConfig(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying global configs: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -79,12 +84,12 @@ var globalConfigsCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
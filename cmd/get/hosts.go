@@ -31,8 +31,10 @@ type FormattedHost struct {
 	HypervisorType  string `json:"hypervisorType" yaml:"hypervisorType" header:"HYPERVISOR TYPE"`
 	State           string `json:"state" yaml:"state" header:"STATE"`
 	Status          string `json:"status" yaml:"status" header:"STATUS"`
-	ClusterUuid     string `json:"clusterUuid" yaml:"clusterUuid" header:"CLUSTER UUID"`
-	ZoneUuid        string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID"`
+	ClusterName     string `json:"clusterName,omitempty" yaml:"clusterName,omitempty" header:"CLUSTER"`
+	ClusterUuid     string `json:"clusterUuid" yaml:"clusterUuid" header:"CLUSTER UUID" resolve:"ClusterName"`
+	ZoneName        string `json:"zoneName,omitempty" yaml:"zoneName,omitempty" header:"ZONE"`
+	ZoneUuid        string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID" resolve:"ZoneName"`
 	TotalCpu        string `json:"totalCpu" yaml:"totalCpu" header:"TOTAL CPU"`
 	AvailableCpu    string `json:"availableCpu" yaml:"availableCpu" header:"AVAILABLE CPU"`
 	TotalMemory     string `json:"totalMemory" yaml:"totalMemory" header:"TOTAL MEMORY"`
@@ -47,7 +49,7 @@ var hostsCmd = &cobra.Command{
 	Use:   "hosts [name]",
 	Short: "List physical hosts",
 	Long:  `List all physical hosts in the ZStack cloud platform.`,
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -62,11 +64,16 @@ var hostsCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/hosts") {
+			return
+		}
+
 		hosts, err := zsClient.QueryHost(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying hosts: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		if len(hosts) == 0 {
 			fmt.Println("No hosts found.")
@@ -100,12 +107,27 @@ var hostsCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		names := common.NameResolverFromFlags(cobraCmd, zsClient)
+		for _, r := range formattedResults {
+			names.Add(client.KindCluster, r.ClusterUuid)
+			names.Add(client.KindZone, r.ZoneUuid)
+		}
+		if err := names.Fetch(); err != nil {
+			fmt.Printf("Error resolving names: %s\n", err)
+			return
+		}
+		for i := range formattedResults {
+			r := &formattedResults[i]
+			r.ClusterName = names.Name(r.ClusterUuid)
+			r.ZoneName = names.Name(r.ZoneUuid)
+		}
+
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func formatCpuCapacity(cpuHz int64) string {
@@ -116,4 +138,11 @@ func formatCpuCapacity(cpuHz int64) string {
 func init() {
 	GetCmd.AddCommand(hostsCmd)
 	common.AddQueryFlags(hostsCmd)
+	common.AddFilterFlags(hostsCmd,
+		common.FilterFlag{Name: "state", Field: "state", Type: common.FilterEnum, Usage: "host state", Values: []string{"Enabled", "Disabled", "PreMaintenance", "Maintenance"}},
+		common.FilterFlag{Name: "status", Field: "status", Type: common.FilterEnum, Usage: "host status", Values: []string{"Connecting", "Connected", "Disconnected"}},
+		common.FilterFlag{Name: "ip", Field: "managementIp", Type: common.FilterPattern, Usage: "management IP"},
+		common.CreatedFilter(),
+	)
+	common.AddResolveNamesFlag(hostsCmd)
 }
@@ -76,7 +76,7 @@ Examples:
   zstack-cli get image-storages --output json
   zstack-cli get image-storages --output yaml
   zstack-cli get image-storages --output text`,
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -90,6 +90,10 @@ Examples:
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/backup-storage") {
+			return
+		}
+
 		var backupStorages []view.BackupStorageInventoryView
 		backupStorages, err = zsClient.QueryBackupStorage(*queryParam)
 
@@ -100,6 +104,7 @@ Examples:
 			fmt.Printf("Debug: Query parameters: %+v\n", queryParam)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		if len(backupStorages) == 0 {
 			fmt.Println("No image storages found.")
@@ -125,12 +130,12 @@ Examples:
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
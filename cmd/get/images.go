@@ -19,8 +19,10 @@ import (
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
 	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
 	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
 )
 
 type FormattedImage struct {
@@ -42,7 +44,7 @@ var imagesCmd = &cobra.Command{
 	Use:   "images [name]",
 	Short: "List images",
 	Long:  `List all images in the ZStack cloud platform.`,
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -56,45 +58,74 @@ var imagesCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/images") {
+			return
+		}
+
+		if common.AllPages(cobraCmd) {
+			err = common.StreamAllPages(cobraCmd, queryParam, zsClient.QueryImage, func(images []view.ImageView) (interface{}, error) {
+				return formatImages(images), nil
+			})
+			if err != nil {
+				fmt.Printf("Error querying images: %s\n", err)
+			}
+			return
+		}
+
 		images, err := zsClient.QueryImage(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying images: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
 		fields, _ := cobraCmd.Flags().GetStringSlice("fields")
 
-		var formattedResults []FormattedImage
-		for _, image := range images {
-			formatted := FormattedImage{
-				Name:         image.Name,
-				UUID:         image.UUID,
-				State:        image.State,
-				Status:       image.Status,
-				Size:         utils.FormatDiskSize(image.Size),
-				ActualSize:   utils.FormatDiskSize(image.ActualSize),
-				Format:       image.Format,
-				MediaType:    image.MediaType,
-				Platform:     image.Platform,
-				Architecture: string(image.Architecture),
-				Type:         image.Type,
-				GuestOsType:  image.GuestOsType,
-			}
-			formattedResults = append(formattedResults, formatted)
-		}
+		formattedResults := formatImages(images)
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(imagesCmd)
 
 	common.AddQueryFlags(imagesCmd)
+	common.AddFilterFlags(imagesCmd,
+		common.FilterFlag{Name: "state", Field: "state", Type: common.FilterEnum, Usage: "image state", Values: types.States},
+		common.FilterFlag{Name: "status", Field: "status", Type: common.FilterEnum, Usage: "image status", Values: types.ImageStatuses},
+		common.FilterFlag{Name: "media-type", Field: "mediaType", Type: common.FilterEnum, Usage: "media type", Values: []string{"RootVolumeTemplate", "DataVolumeTemplate", "ISO"}},
+		common.FilterFlag{Name: "size", Field: "size", Type: common.FilterSize, Usage: "image size"},
+		common.CreatedFilter(),
+	)
+	common.AddPagingFlags(imagesCmd)
+}
+
+func formatImages(images []view.ImageView) []FormattedImage {
+	var formattedResults []FormattedImage
+	for _, image := range images {
+		formatted := FormattedImage{
+			Name:         image.Name,
+			UUID:         image.UUID,
+			State:        image.State,
+			Status:       image.Status,
+			Size:         utils.FormatDiskSize(image.Size),
+			ActualSize:   utils.FormatDiskSize(image.ActualSize),
+			Format:       image.Format,
+			MediaType:    image.MediaType,
+			Platform:     image.Platform,
+			Architecture: string(image.Architecture),
+			Type:         image.Type,
+			GuestOsType:  image.GuestOsType,
+		}
+		formattedResults = append(formattedResults, formatted)
+	}
+
+	return formattedResults
 }
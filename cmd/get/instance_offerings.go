@@ -37,7 +37,7 @@ var instanceOfferingsCmd = &cobra.Command{
 	Use:   "instance-offerings [name]",
 	Short: "List instance offerings",
 	Long:  `List all instance offerings in the ZStack cloud platform.`,
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -51,11 +51,16 @@ var instanceOfferingsCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/instance-offerings") {
+			return
+		}
+
 		instanceOfferings, err := zsClient.QueryInstaceOffering(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying instance offerings: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -75,12 +80,12 @@ var instanceOfferingsCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
@@ -20,6 +20,7 @@ import (
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
 	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
 	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
 
@@ -31,17 +32,24 @@ type FormattedVmInstance struct {
 	UUID        string `json:"uuid" yaml:"uuid" header:"UUID"`
 	Description string `json:"description" yaml:"description" header:"DESCRIPTION"`
 
-	ZoneUUID             string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID"`
-	ClusterUUID          string `json:"clusterUuid" yaml:"clusterUuid" header:"CLUSTER UUID"`
-	ImageUUID            string `json:"imageUuid" yaml:"imageUuid" header:"IMAGE UUID"`
-	HostUUID             string `json:"hostUuid" yaml:"hostUuid" header:"HOST UUID"`
-	LastHostUUID         string `json:"lastHostUuid" yaml:"lastHostUuid" header:"LAST HOST UUID"`
-	InstanceOfferingUUID string `json:"instanceOfferingUuid" yaml:"instanceOfferingUuid" header:"INSTANCE OFFERING UUID"`
+	ZoneName             string `json:"zoneName,omitempty" yaml:"zoneName,omitempty" header:"ZONE"`
+	ZoneUUID             string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID" resolve:"ZoneName"`
+	ClusterName          string `json:"clusterName,omitempty" yaml:"clusterName,omitempty" header:"CLUSTER"`
+	ClusterUUID          string `json:"clusterUuid" yaml:"clusterUuid" header:"CLUSTER UUID" resolve:"ClusterName"`
+	ImageName            string `json:"imageName,omitempty" yaml:"imageName,omitempty" header:"IMAGE"`
+	ImageUUID            string `json:"imageUuid" yaml:"imageUuid" header:"IMAGE UUID" resolve:"ImageName"`
+	HostName             string `json:"hostName,omitempty" yaml:"hostName,omitempty" header:"HOST"`
+	HostUUID             string `json:"hostUuid" yaml:"hostUuid" header:"HOST UUID" resolve:"HostName"`
+	LastHostName         string `json:"lastHostName,omitempty" yaml:"lastHostName,omitempty" header:"LAST HOST"`
+	LastHostUUID         string `json:"lastHostUuid" yaml:"lastHostUuid" header:"LAST HOST UUID" resolve:"LastHostName"`
+	InstanceOfferingName string `json:"instanceOfferingName,omitempty" yaml:"instanceOfferingName,omitempty" header:"INSTANCE OFFERING"`
+	InstanceOfferingUUID string `json:"instanceOfferingUuid" yaml:"instanceOfferingUuid" header:"INSTANCE OFFERING UUID" resolve:"InstanceOfferingName"`
 	RootVolumeUUID       string `json:"rootVolumeUuid" yaml:"rootVolumeUuid" header:"ROOT VOLUME UUID"`
 	Platform             string `json:"platform" yaml:"platform" header:"PLATFORM"`
 	Architecture         string `json:"architecture" yaml:"architecture" header:"ARCHITECTURE"`
 	GuestOsType          string `json:"guestOsType" yaml:"guestOsType" header:"GUEST OS TYPE"`
-	DefaultL3NetworkUUID string `json:"defaultL3NetworkUuid" yaml:"defaultL3NetworkUuid" header:"DEFAULT L3 NETWORK UUID"`
+	DefaultL3NetworkName string `json:"defaultL3NetworkName,omitempty" yaml:"defaultL3NetworkName,omitempty" header:"DEFAULT L3 NETWORK"`
+	DefaultL3NetworkUUID string `json:"defaultL3NetworkUuid" yaml:"defaultL3NetworkUuid" header:"DEFAULT L3 NETWORK UUID" resolve:"DefaultL3NetworkName"`
 	Type                 string `json:"type" yaml:"type" header:"TYPE"`
 	HypervisorType       string `json:"hypervisorType" yaml:"hypervisorType" header:"HYPERVISOR TYPE"`
 	MemorySize           string `json:"memorySize" yaml:"memorySize" header:"MEMORY SIZE"`
@@ -58,7 +66,7 @@ var vmInstancesCmd = &cobra.Command{
 	Short: "List VM instances",
 	Long:  `List all VM instances in the ZStack cloud platform.`,
 	Args:  cobra.MaximumNArgs(1),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -78,6 +86,21 @@ var vmInstancesCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/vm-instances") {
+			return
+		}
+
+		names := common.NameResolverFromFlags(cobraCmd, zsClient)
+		if common.AllPages(cobraCmd) {
+			err = common.StreamAllPages(cobraCmd, queryParam, zsClient.QueryVmInstance, func(vmInstances []view.VmInstanceInventoryView) (interface{}, error) {
+				return formatVmInstances(vmInstances, names)
+			})
+			if err != nil {
+				fmt.Printf("Error querying VM instances: %s\n", err)
+			}
+			return
+		}
+
 		usePagination, _ := cobraCmd.Flags().GetBool("pagination")
 		var vmInstances []view.VmInstanceInventoryView
 		var total int
@@ -97,6 +120,7 @@ var vmInstancesCmd = &cobra.Command{
 				fmt.Printf("Error querying VM instances: %s\n", err)
 				return
 			}
+			common.PrintReplyCount(cobraCmd, queryParam)
 		}
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
@@ -104,63 +128,105 @@ var vmInstancesCmd = &cobra.Command{
 
 		fields, _ := cobraCmd.Flags().GetStringSlice("fields")
 
-		var formattedResults []FormattedVmInstance
-		for _, vm := range vmInstances {
-
-			var ips []string
-			for _, nic := range vm.VMNics {
-				if nic.IP != "" {
-					ips = append(ips, nic.IP)
-				}
-			}
-
-			var volumes []string
-			for _, vol := range vm.AllVolumes {
-				volumes = append(volumes, vol.Name)
-			}
-
-			formatted := FormattedVmInstance{
-				Name:        vm.Name,
-				UUID:        vm.UUID,
-				Description: vm.Description,
-
-				ZoneUUID:             vm.ZoneUUID,
-				ClusterUUID:          vm.ClusterUUID,
-				ImageUUID:            vm.ImageUUID,
-				HostUUID:             vm.HostUUID,
-				LastHostUUID:         vm.LastHostUUID,
-				InstanceOfferingUUID: vm.InstanceOfferingUUID,
-				RootVolumeUUID:       vm.RootVolumeUUID,
-				Platform:             vm.Platform,
-				Architecture:         vm.Architecture,
-				GuestOsType:          vm.GuestOsType,
-				DefaultL3NetworkUUID: vm.DefaultL3NetworkUUID,
-				Type:                 vm.Type,
-				HypervisorType:       vm.HypervisorType,
-				MemorySize:           utils.FormatMemorySize(vm.MemorySize),
-				CPUNum:               vm.CPUNum,
-				CPUSpeed:             vm.CPUSpeed,
-				AllocatorStrategy:    vm.AllocatorStrategy,
-				State:                vm.State,
-				IPs:                  strings.Join(ips, ", "),
-				Volumes:              strings.Join(volumes, ", "),
-			}
-			formattedResults = append(formattedResults, formatted)
+		formattedResults, err := formatVmInstances(vmInstances, names)
+		if err != nil {
+			fmt.Printf("Error resolving names: %s\n", err)
+			return
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(vmInstancesCmd)
 	common.AddQueryFlags(vmInstancesCmd)
+	common.AddFilterFlags(vmInstancesCmd,
+		common.FilterFlag{Name: "state", Field: "state", Type: common.FilterEnum, Usage: "VM state", Values: types.VMStates},
+		common.FilterFlag{Name: "memory", Field: "memorySize", Type: common.FilterSize, Usage: "memory size"},
+		common.FilterFlag{Name: "ip", Field: "vmNics.ip", Type: common.FilterPattern, Usage: "NIC IP address"},
+		common.FilterFlag{Name: "image", Field: "imageUuid", Type: common.FilterLookup, Usage: "image", Lookup: client.GetImageUUIDByName},
+		common.CreatedFilter(),
+	)
+	common.AddPagingFlags(vmInstancesCmd)
+	common.AddResolveNamesFlag(vmInstancesCmd)
 	vmInstancesCmd.Flags().Bool("pagination", false, "Use pagination when querying VM instances")
 	vmInstancesCmd.Flags().StringP("zone", "z", "", "Filter by zone name or UUID")
 	vmInstancesCmd.Flags().StringP("cluster", "c", "", "Filter by cluster name or UUID")
 	vmInstancesCmd.Flags().StringP("host", "H", "", "Filter by host name or UUID")
 }
+
+func formatVmInstances(vmInstances []view.VmInstanceInventoryView, names *client.NameResolver) ([]FormattedVmInstance, error) {
+	var formattedResults []FormattedVmInstance
+	for _, vm := range vmInstances {
+
+		var ips []string
+		for _, nic := range vm.VMNics {
+			if nic.IP != "" {
+				ips = append(ips, nic.IP)
+			}
+		}
+
+		var volumes []string
+		for _, vol := range vm.AllVolumes {
+			volumes = append(volumes, vol.Name)
+		}
+
+		formatted := FormattedVmInstance{
+			Name:        vm.Name,
+			UUID:        vm.UUID,
+			Description: vm.Description,
+
+			ZoneUUID:             vm.ZoneUUID,
+			ClusterUUID:          vm.ClusterUUID,
+			ImageUUID:            vm.ImageUUID,
+			HostUUID:             vm.HostUUID,
+			LastHostUUID:         vm.LastHostUUID,
+			InstanceOfferingUUID: vm.InstanceOfferingUUID,
+			RootVolumeUUID:       vm.RootVolumeUUID,
+			Platform:             vm.Platform,
+			Architecture:         vm.Architecture,
+			GuestOsType:          vm.GuestOsType,
+			DefaultL3NetworkUUID: vm.DefaultL3NetworkUUID,
+			Type:                 vm.Type,
+			HypervisorType:       vm.HypervisorType,
+			MemorySize:           utils.FormatMemorySize(vm.MemorySize),
+			CPUNum:               vm.CPUNum,
+			CPUSpeed:             vm.CPUSpeed,
+			AllocatorStrategy:    vm.AllocatorStrategy,
+			State:                vm.State,
+			IPs:                  strings.Join(ips, ", "),
+			Volumes:              strings.Join(volumes, ", "),
+		}
+		formattedResults = append(formattedResults, formatted)
+	}
+
+	for _, r := range formattedResults {
+		names.Add(client.KindZone, r.ZoneUUID)
+		names.Add(client.KindCluster, r.ClusterUUID)
+		names.Add(client.KindImage, r.ImageUUID)
+		names.Add(client.KindHost, r.HostUUID)
+		names.Add(client.KindHost, r.LastHostUUID)
+		names.Add(client.KindInstanceOffering, r.InstanceOfferingUUID)
+		names.Add(client.KindL3Network, r.DefaultL3NetworkUUID)
+	}
+	if err := names.Fetch(); err != nil {
+		return nil, err
+	}
+	for i := range formattedResults {
+		r := &formattedResults[i]
+		r.ZoneName = names.Name(r.ZoneUUID)
+		r.ClusterName = names.Name(r.ClusterUUID)
+		r.ImageName = names.Name(r.ImageUUID)
+		r.HostName = names.Name(r.HostUUID)
+		r.LastHostName = names.Name(r.LastHostUUID)
+		r.InstanceOfferingName = names.Name(r.InstanceOfferingUUID)
+		r.DefaultL3NetworkName = names.Name(r.DefaultL3NetworkUUID)
+	}
+
+	return formattedResults, nil
+}
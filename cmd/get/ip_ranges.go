@@ -26,7 +26,8 @@ import (
 type FormattedIpRange struct {
 	Name          string `json:"name" yaml:"name" header:"NAME"`
 	UUID          string `json:"uuid" yaml:"uuid" header:"UUID"`
-	L3NetworkUUID string `json:"l3NetworkUuid" yaml:"l3NetworkUuid" header:"L3 NETWORK"`
+	L3NetworkName string `json:"l3NetworkName,omitempty" yaml:"l3NetworkName,omitempty" header:"L3 NETWORK"`
+	L3NetworkUUID string `json:"l3NetworkUuid" yaml:"l3NetworkUuid" header:"L3 NETWORK UUID" resolve:"L3NetworkName"`
 	StartIP       string `json:"startIp" yaml:"startIp" header:"START IP"`
 	EndIP         string `json:"endIp" yaml:"endIp" header:"END IP"`
 	Netmask       string `json:"netmask" yaml:"netmask" header:"NETMASK"`
@@ -43,7 +44,7 @@ var ipRangesCmd = &cobra.Command{
 	Short:   "List IP ranges",
 	Long:    `List all IP ranges for L3 networks in the ZStack cloud platform.`,
 	Args:    cobra.MaximumNArgs(1),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -57,11 +58,16 @@ var ipRangesCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/l3-networks/ip-ranges") {
+			return
+		}
+
 		ipRanges, err := zsClient.QueryIpRange(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying IP ranges: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -85,15 +91,29 @@ var ipRangesCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		names := common.NameResolverFromFlags(cobraCmd, zsClient)
+		for _, r := range formattedResults {
+			names.Add(client.KindL3Network, r.L3NetworkUUID)
+		}
+		if err := names.Fetch(); err != nil {
+			fmt.Printf("Error resolving names: %s\n", err)
+			return
+		}
+		for i := range formattedResults {
+			r := &formattedResults[i]
+			r.L3NetworkName = names.Name(r.L3NetworkUUID)
+		}
+
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(ipRangesCmd)
 	common.AddQueryFlags(ipRangesCmd)
+	common.AddResolveNamesFlag(ipRangesCmd)
 }
@@ -29,14 +29,15 @@ type FormattedL2Network struct {
 	Type              string `json:"type" yaml:"type" header:"TYPE"`
 	Vlan              int    `json:"vlan" yaml:"vlan" header:"VLAN"`
 	PhysicalInterface string `json:"physicalInterface" yaml:"physicalInterface" header:"PHYSICAL INTERFACE"`
-	ZoneUuid          string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID"`
+	ZoneName          string `json:"zoneName,omitempty" yaml:"zoneName,omitempty" header:"ZONE"`
+	ZoneUuid          string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID" resolve:"ZoneName"`
 }
 
 var l2NetworksCmd = &cobra.Command{
 	Use:   "l2-networks [name]",
 	Short: "List L2 networks",
 	Long:  `List all Layer 2 networks in the ZStack cloud platform.`,
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -50,11 +51,16 @@ var l2NetworksCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/l2-networks") {
+			return
+		}
+
 		l2Networks, err := zsClient.QueryL2Network(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying L2 networks: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -73,16 +79,30 @@ var l2NetworksCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		names := common.NameResolverFromFlags(cobraCmd, zsClient)
+		for _, r := range formattedResults {
+			names.Add(client.KindZone, r.ZoneUuid)
+		}
+		if err := names.Fetch(); err != nil {
+			fmt.Printf("Error resolving names: %s\n", err)
+			return
+		}
+		for i := range formattedResults {
+			r := &formattedResults[i]
+			r.ZoneName = names.Name(r.ZoneUuid)
+		}
+
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(l2NetworksCmd)
 
 	common.AddQueryFlags(l2NetworksCmd)
+	common.AddResolveNamesFlag(l2NetworksCmd)
 }
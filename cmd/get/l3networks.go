@@ -30,8 +30,10 @@ type FormattedL3Network struct {
 	UUID          string `json:"uuid" yaml:"uuid" header:"UUID"`
 	Type          string `json:"type" yaml:"type" header:"TYPE"`
 	State         string `json:"state" yaml:"state" header:"STATE"`
-	L2NetworkUuid string `json:"l2NetworkUuid" yaml:"l2NetworkUuid" header:"L2 NETWORK UUID"`
-	ZoneUuid      string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID"`
+	L2NetworkName string `json:"l2NetworkName,omitempty" yaml:"l2NetworkName,omitempty" header:"L2 NETWORK"`
+	L2NetworkUuid string `json:"l2NetworkUuid" yaml:"l2NetworkUuid" header:"L2 NETWORK UUID" resolve:"L2NetworkName"`
+	ZoneName      string `json:"zoneName,omitempty" yaml:"zoneName,omitempty" header:"ZONE"`
+	ZoneUuid      string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID" resolve:"ZoneName"`
 	IpVersion     int    `json:"ipVersion" yaml:"ipVersion" header:"IP VERSION"`
 	DnsDomain     string `json:"dnsDomain" yaml:"dnsDomain" header:"DNS DOMAIN"`
 	Dns           string `json:"dns" yaml:"dns" header:"DNS"`
@@ -44,7 +46,7 @@ var l3NetworksCmd = &cobra.Command{
 	Use:   "l3-networks [name]",
 	Short: "List L3 networks",
 	Long:  `List all Layer 3 networks in the ZStack cloud platform.`,
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -58,11 +60,16 @@ var l3NetworksCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/l3-networks") {
+			return
+		}
+
 		l3Networks, err := zsClient.QueryL3Network(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying L3 networks: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -88,17 +95,33 @@ var l3NetworksCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		names := common.NameResolverFromFlags(cobraCmd, zsClient)
+		for _, r := range formattedResults {
+			names.Add(client.KindL2Network, r.L2NetworkUuid)
+			names.Add(client.KindZone, r.ZoneUuid)
+		}
+		if err := names.Fetch(); err != nil {
+			fmt.Printf("Error resolving names: %s\n", err)
+			return
+		}
+		for i := range formattedResults {
+			r := &formattedResults[i]
+			r.L2NetworkName = names.Name(r.L2NetworkUuid)
+			r.ZoneName = names.Name(r.ZoneUuid)
+		}
+
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(l3NetworksCmd)
 
 	common.AddQueryFlags(l3NetworksCmd)
+	common.AddResolveNamesFlag(l3NetworksCmd)
 
 }
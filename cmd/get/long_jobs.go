@@ -41,7 +41,7 @@ var longJobsCmd = &cobra.Command{
 	Short:   "List long-running async jobs",
 	Long:    `List all long-running async jobs in the ZStack cloud platform.`,
 	Args:    cobra.MaximumNArgs(1),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -55,6 +55,10 @@ var longJobsCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/longjobs") {
+			return
+		}
+
 		usePagination, _ := cobraCmd.Flags().GetBool("pagination")
 		var jobs []view.LongJobInventoryView
 		var total int
@@ -72,6 +76,7 @@ var longJobsCmd = &cobra.Command{
 				fmt.Printf("Error querying long jobs: %s\n", err)
 				return
 			}
+			common.PrintReplyCount(cobraCmd, queryParam)
 		}
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
@@ -93,12 +98,12 @@ var longJobsCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
@@ -30,7 +30,7 @@ var managementNodesCmd = &cobra.Command{
 	Short: "Query management nodes",
 	Long:  `Query management nodes in the ZStack cloud platform.`,
 	Args:  cobra.MaximumNArgs(1),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		fmt.Printf("Debug: Command arguments: %v\n", args)
 
@@ -48,11 +48,16 @@ var managementNodesCmd = &cobra.Command{
 
 		fmt.Printf("Debug: Query parameters: %+v\n", queryParam)
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/management-nodes") {
+			return
+		}
+
 		nodes, err := zsClient.QueryManagementNode(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying management nodes: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -85,11 +90,11 @@ var managementNodesCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, processedFields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, processedFields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 		}
-	},
+	}),
 }
 
 type FormattedManagementNode struct {
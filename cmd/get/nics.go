@@ -27,8 +27,10 @@ import (
 
 type FormattedVmNic struct {
 	UUID           string `json:"uuid" yaml:"uuid" header:"UUID"`
-	VMInstanceUUID string `json:"vmInstanceUuid" yaml:"vmInstanceUuid" header:"VM UUID"`
-	L3NetworkUUID  string `json:"l3NetworkUuid" yaml:"l3NetworkUuid" header:"L3 NETWORK"`
+	VMInstanceName string `json:"vmInstanceName,omitempty" yaml:"vmInstanceName,omitempty" header:"VM"`
+	VMInstanceUUID string `json:"vmInstanceUuid" yaml:"vmInstanceUuid" header:"VM UUID" resolve:"VMInstanceName"`
+	L3NetworkName  string `json:"l3NetworkName,omitempty" yaml:"l3NetworkName,omitempty" header:"L3 NETWORK"`
+	L3NetworkUUID  string `json:"l3NetworkUuid" yaml:"l3NetworkUuid" header:"L3 NETWORK UUID" resolve:"L3NetworkName"`
 	IP             string `json:"ip" yaml:"ip" header:"IP"`
 	Mac            string `json:"mac" yaml:"mac" header:"MAC"`
 	Netmask        string `json:"netmask" yaml:"netmask" header:"NETMASK"`
@@ -45,7 +47,7 @@ var nicsCmd = &cobra.Command{
 	Short:   "List VM network interfaces",
 	Long:    `List all VM network interfaces (NICs) in the ZStack cloud platform.`,
 	Args:    cobra.MaximumNArgs(0),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -59,6 +61,21 @@ var nicsCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/vm-instances/nics") {
+			return
+		}
+
+		names := common.NameResolverFromFlags(cobraCmd, zsClient)
+		if common.AllPages(cobraCmd) {
+			err = common.StreamAllPages(cobraCmd, queryParam, zsClient.QueryVmNic, func(nics []view.VmNicInventoryView) (interface{}, error) {
+				return formatVmNics(nics, names)
+			})
+			if err != nil {
+				fmt.Printf("Error querying VM NICs: %s\n", err)
+			}
+			return
+		}
+
 		usePagination, _ := cobraCmd.Flags().GetBool("pagination")
 		var nics []view.VmNicInventoryView
 		var total int
@@ -76,50 +93,80 @@ var nicsCmd = &cobra.Command{
 				fmt.Printf("Error querying VM NICs: %s\n", err)
 				return
 			}
+			common.PrintReplyCount(cobraCmd, queryParam)
 		}
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
 		fields, _ := cobraCmd.Flags().GetStringSlice("fields")
 
-		var formattedResults []FormattedVmNic
-		for _, nic := range nics {
-			// Get IP addresses from the nic
-			var ips []string
-			for _, usedIp := range nic.UsedIps {
-				ips = append(ips, usedIp.Ip)
-			}
-			ipStr := strings.Join(ips, ", ")
-			if ipStr == "" {
-				ipStr = nic.IP
-			}
-
-			formatted := FormattedVmNic{
-				UUID:           nic.UUID,
-				VMInstanceUUID: nic.VMInstanceUUID,
-				L3NetworkUUID:  nic.L3NetworkUUID,
-				IP:             ipStr,
-				Mac:            nic.Mac,
-				Netmask:        nic.Netmask,
-				Gateway:        nic.Gateway,
-				IPVersion:      nic.IpVersion,
-				DeviceID:       nic.DeviceID,
-				Type:           nic.Type,
-				DriverType:     nic.DriverType,
-			}
-			formattedResults = append(formattedResults, formatted)
+		formattedResults, err := formatVmNics(nics, names)
+		if err != nil {
+			fmt.Printf("Error resolving names: %s\n", err)
+			return
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(nicsCmd)
 	common.AddQueryFlags(nicsCmd)
+	common.AddFilterFlags(nicsCmd,
+		common.FilterFlag{Name: "ip", Field: "ip", Type: common.FilterPattern, Usage: "IP address"},
+		common.FilterFlag{Name: "l3-network", Field: "l3NetworkUuid", Type: common.FilterLookup, Usage: "L3 network", Lookup: client.GetL3NetworkUUIDByName},
+	)
+	common.AddPagingFlags(nicsCmd)
+	common.AddResolveNamesFlag(nicsCmd)
 	nicsCmd.Flags().Bool("pagination", false, "Use pagination when querying NICs")
 }
+
+func formatVmNics(nics []view.VmNicInventoryView, names *client.NameResolver) ([]FormattedVmNic, error) {
+	var formattedResults []FormattedVmNic
+	for _, nic := range nics {
+		// Get IP addresses from the nic
+		var ips []string
+		for _, usedIp := range nic.UsedIps {
+			ips = append(ips, usedIp.Ip)
+		}
+		ipStr := strings.Join(ips, ", ")
+		if ipStr == "" {
+			ipStr = nic.IP
+		}
+
+		formatted := FormattedVmNic{
+			UUID:           nic.UUID,
+			VMInstanceUUID: nic.VMInstanceUUID,
+			L3NetworkUUID:  nic.L3NetworkUUID,
+			IP:             ipStr,
+			Mac:            nic.Mac,
+			Netmask:        nic.Netmask,
+			Gateway:        nic.Gateway,
+			IPVersion:      nic.IpVersion,
+			DeviceID:       nic.DeviceID,
+			Type:           nic.Type,
+			DriverType:     nic.DriverType,
+		}
+		formattedResults = append(formattedResults, formatted)
+	}
+
+	for _, r := range formattedResults {
+		names.Add(client.KindVmInstance, r.VMInstanceUUID)
+		names.Add(client.KindL3Network, r.L3NetworkUUID)
+	}
+	if err := names.Fetch(); err != nil {
+		return nil, err
+	}
+	for i := range formattedResults {
+		r := &formattedResults[i]
+		r.VMInstanceName = names.Name(r.VMInstanceUUID)
+		r.L3NetworkName = names.Name(r.L3NetworkUUID)
+	}
+
+	return formattedResults, nil
+}
@@ -28,7 +28,8 @@ type FormattedPrimaryStorage struct {
 	Name                      string `json:"name" yaml:"name" header:"NAME"`
 	UUID                      string `json:"uuid" yaml:"uuid" header:"UUID"`
 	Description               string `json:"description" yaml:"description" header:"DESCRIPTION"`
-	ZoneUuid                  string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID"`
+	ZoneName                  string `json:"zoneName,omitempty" yaml:"zoneName,omitempty" header:"ZONE"`
+	ZoneUuid                  string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID" resolve:"ZoneName"`
 	Url                       string `json:"url" yaml:"url" header:"URL"`
 	TotalCapacity             string `json:"totalCapacity" yaml:"totalCapacity" header:"TOTAL CAPACITY"`
 	AvailableCapacity         string `json:"availableCapacity" yaml:"availableCapacity" header:"AVAILABLE CAPACITY"`
@@ -49,7 +50,7 @@ var primaryStorageCmd = &cobra.Command{
 	Short: "List primary storages",
 	Long:  `List all primary storages in the ZStack cloud platform.`,
 	Args:  cobra.MaximumNArgs(1),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -63,11 +64,16 @@ var primaryStorageCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/primary-storage") {
+			return
+		}
+
 		primaryStorages, err := zsClient.QueryPrimaryStorage(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying primary storages: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -98,17 +104,31 @@ var primaryStorageCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		names := common.NameResolverFromFlags(cobraCmd, zsClient)
+		for _, r := range formattedResults {
+			names.Add(client.KindZone, r.ZoneUuid)
+		}
+		if err := names.Fetch(); err != nil {
+			fmt.Printf("Error resolving names: %s\n", err)
+			return
+		}
+		for i := range formattedResults {
+			r := &formattedResults[i]
+			r.ZoneName = names.Name(r.ZoneUuid)
+		}
+
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(primaryStorageCmd)
 
 	common.AddQueryFlags(primaryStorageCmd)
+	common.AddResolveNamesFlag(primaryStorageCmd)
 
 }
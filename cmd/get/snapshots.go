@@ -46,7 +46,7 @@ var snapshotsCmd = &cobra.Command{
 	Short:   "List volume snapshots",
 	Long:    `List all volume snapshots in the ZStack cloud platform.`,
 	Args:    cobra.MaximumNArgs(1),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -60,6 +60,20 @@ var snapshotsCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/volume-snapshots") {
+			return
+		}
+
+		if common.AllPages(cobraCmd) {
+			err = common.StreamAllPages(cobraCmd, queryParam, zsClient.QueryVolumeSnapshot, func(snapshots []view.VolumeSnapshotView) (interface{}, error) {
+				return formatVolumeSnapshots(snapshots), nil
+			})
+			if err != nil {
+				fmt.Printf("Error querying volume snapshots: %s\n", err)
+			}
+			return
+		}
+
 		usePagination, _ := cobraCmd.Flags().GetBool("pagination")
 		var snapshots []view.VolumeSnapshotView
 		var total int
@@ -77,42 +91,50 @@ var snapshotsCmd = &cobra.Command{
 				fmt.Printf("Error querying volume snapshots: %s\n", err)
 				return
 			}
+			common.PrintReplyCount(cobraCmd, queryParam)
 		}
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
 		fields, _ := cobraCmd.Flags().GetStringSlice("fields")
 
-		var formattedResults []FormattedVolumeSnapshot
-		for _, snapshot := range snapshots {
-			formatted := FormattedVolumeSnapshot{
-				Name:             snapshot.Name,
-				UUID:             snapshot.UUID,
-				Description:      snapshot.Description,
-				Type:             snapshot.Type,
-				VolumeUUID:       snapshot.VolumeUUID,
-				TreeUUID:         snapshot.TreeUUID,
-				ParentUUID:       snapshot.ParentUUID,
-				PrimaryStorageID: snapshot.PrimaryStorageUUID,
-				Size:             utils.FormatDiskSize(snapshot.Size),
-				State:            snapshot.State,
-				Status:           snapshot.Status,
-				Latest:           snapshot.Latest,
-				CreateDate:       snapshot.CreateDate.Format("2006-01-02 15:04:05"),
-			}
-			formattedResults = append(formattedResults, formatted)
-		}
+		formattedResults := formatVolumeSnapshots(snapshots)
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(snapshotsCmd)
 	common.AddQueryFlags(snapshotsCmd)
+	common.AddPagingFlags(snapshotsCmd)
 	snapshotsCmd.Flags().Bool("pagination", false, "Use pagination when querying snapshots")
 }
+
+func formatVolumeSnapshots(snapshots []view.VolumeSnapshotView) []FormattedVolumeSnapshot {
+	var formattedResults []FormattedVolumeSnapshot
+	for _, snapshot := range snapshots {
+		formatted := FormattedVolumeSnapshot{
+			Name:             snapshot.Name,
+			UUID:             snapshot.UUID,
+			Description:      snapshot.Description,
+			Type:             snapshot.Type,
+			VolumeUUID:       snapshot.VolumeUUID,
+			TreeUUID:         snapshot.TreeUUID,
+			ParentUUID:       snapshot.ParentUUID,
+			PrimaryStorageID: snapshot.PrimaryStorageUUID,
+			Size:             utils.FormatDiskSize(snapshot.Size),
+			State:            snapshot.State,
+			Status:           snapshot.Status,
+			Latest:           snapshot.Latest,
+			CreateDate:       snapshot.CreateDate.Format("2006-01-02 15:04:05"),
+		}
+		formattedResults = append(formattedResults, formatted)
+	}
+
+	return formattedResults
+}
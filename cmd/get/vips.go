@@ -28,7 +28,8 @@ type FormattedVip struct {
 	Name               string `json:"name" yaml:"name" header:"NAME"`
 	UUID               string `json:"uuid" yaml:"uuid" header:"UUID"`
 	Description        string `json:"description" yaml:"description" header:"DESCRIPTION"`
-	L3NetworkUUID      string `json:"l3NetworkUuid" yaml:"l3NetworkUuid" header:"L3 NETWORK UUID"`
+	L3NetworkName      string `json:"l3NetworkName,omitempty" yaml:"l3NetworkName,omitempty" header:"L3 NETWORK"`
+	L3NetworkUUID      string `json:"l3NetworkUuid" yaml:"l3NetworkUuid" header:"L3 NETWORK UUID" resolve:"L3NetworkName"`
 	Ip                 string `json:"ip" yaml:"ip" header:"IP"`
 	State              string `json:"state" yaml:"state" header:"STATE"`
 	Gateway            string `json:"gateway" yaml:"gateway" header:"GATEWAY"`
@@ -46,7 +47,7 @@ var vipsCmd = &cobra.Command{
 	Short: "List virtual IPs",
 	Long:  `List all virtual IPs in the ZStack cloud platform.`,
 	Args:  cobra.MaximumNArgs(1),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -60,11 +61,16 @@ var vipsCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/vips") {
+			return
+		}
+
 		vips, err := zsClient.QueryVip(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying virtual IPs: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -99,15 +105,34 @@ var vipsCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		names := common.NameResolverFromFlags(cobraCmd, zsClient)
+		for _, r := range formattedResults {
+			names.Add(client.KindL3Network, r.L3NetworkUUID)
+		}
+		if err := names.Fetch(); err != nil {
+			fmt.Printf("Error resolving names: %s\n", err)
+			return
+		}
+		for i := range formattedResults {
+			r := &formattedResults[i]
+			r.L3NetworkName = names.Name(r.L3NetworkUUID)
+		}
+
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(vipsCmd)
 	common.AddQueryFlags(vipsCmd)
+	common.AddFilterFlags(vipsCmd,
+		common.FilterFlag{Name: "ip", Field: "ip", Type: common.FilterPattern, Usage: "IP address"},
+		common.FilterFlag{Name: "l3-network", Field: "l3NetworkUuid", Type: common.FilterLookup, Usage: "L3 network", Lookup: client.GetL3NetworkUUIDByName},
+		common.CreatedFilter(),
+	)
+	common.AddResolveNamesFlag(vipsCmd)
 }
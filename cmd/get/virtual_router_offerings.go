@@ -48,7 +48,7 @@ var virtualRouterOfferingsCmd = &cobra.Command{
 	Short: "List virtual router offerings",
 	Long:  `List all virtual router offerings in the ZStack cloud platform.`,
 	Args:  cobra.MaximumNArgs(1),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -62,11 +62,16 @@ var virtualRouterOfferingsCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/instance-offerings/virtual-routers") {
+			return
+		}
+
 		offerings, err := zsClient.QueryVirtualRouterOffering(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying virtual router offerings: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -97,12 +102,12 @@ var virtualRouterOfferingsCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
-	},
+	}),
 }
 
 func init() {
@@ -29,19 +29,28 @@ type FormattedVirtualRouter struct {
 	UUID                      string `json:"uuid" yaml:"uuid" header:"UUID"`
 	Description               string `json:"description" yaml:"description" header:"DESCRIPTION"`
 	ApplianceVmType           string `json:"applianceVmType" yaml:"applianceVmType" header:"APPLIANCE VM TYPE"`
-	ManagementNetworkUuid     string `json:"managementNetworkUuid" yaml:"managementNetworkUuid" header:"MANAGEMENT NETWORK UUID"`
-	DefaultRouteL3NetworkUuid string `json:"defaultRouteL3NetworkUuid" yaml:"defaultRouteL3NetworkUuid" header:"DEFAULT ROUTE L3 NETWORK UUID"`
+	ManagementNetworkName     string `json:"managementNetworkName,omitempty" yaml:"managementNetworkName,omitempty" header:"MANAGEMENT NETWORK"`
+	ManagementNetworkUuid     string `json:"managementNetworkUuid" yaml:"managementNetworkUuid" header:"MANAGEMENT NETWORK UUID" resolve:"ManagementNetworkName"`
+	DefaultRouteL3NetworkName string `json:"defaultRouteL3NetworkName,omitempty" yaml:"defaultRouteL3NetworkName,omitempty" header:"DEFAULT ROUTE L3 NETWORK"`
+	DefaultRouteL3NetworkUuid string `json:"defaultRouteL3NetworkUuid" yaml:"defaultRouteL3NetworkUuid" header:"DEFAULT ROUTE L3 NETWORK UUID" resolve:"DefaultRouteL3NetworkName"`
 	Status                    string `json:"status" yaml:"status" header:"STATUS"`
 	AgentPort                 int    `json:"agentPort" yaml:"agentPort" header:"AGENT PORT"`
-	ZoneUuid                  string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID"`
-	ClusterUUID               string `json:"clusterUuid" yaml:"clusterUuid" header:"CLUSTER UUID"`
-	ImageUUID                 string `json:"imageUuid" yaml:"imageUuid" header:"IMAGE UUID"`
-	HostUuid                  string `json:"hostUuid" yaml:"hostUuid" header:"HOST UUID"`
-	LastHostUUID              string `json:"lastHostUuid" yaml:"lastHostUuid" header:"LAST HOST UUID"`
-	InstanceOfferingUUID      string `json:"instanceOfferingUuid" yaml:"instanceOfferingUuid" header:"INSTANCE OFFERING UUID"`
+	ZoneName                  string `json:"zoneName,omitempty" yaml:"zoneName,omitempty" header:"ZONE"`
+	ZoneUuid                  string `json:"zoneUuid" yaml:"zoneUuid" header:"ZONE UUID" resolve:"ZoneName"`
+	ClusterName               string `json:"clusterName,omitempty" yaml:"clusterName,omitempty" header:"CLUSTER"`
+	ClusterUUID               string `json:"clusterUuid" yaml:"clusterUuid" header:"CLUSTER UUID" resolve:"ClusterName"`
+	ImageName                 string `json:"imageName,omitempty" yaml:"imageName,omitempty" header:"IMAGE"`
+	ImageUUID                 string `json:"imageUuid" yaml:"imageUuid" header:"IMAGE UUID" resolve:"ImageName"`
+	HostName                  string `json:"hostName,omitempty" yaml:"hostName,omitempty" header:"HOST"`
+	HostUuid                  string `json:"hostUuid" yaml:"hostUuid" header:"HOST UUID" resolve:"HostName"`
+	LastHostName              string `json:"lastHostName,omitempty" yaml:"lastHostName,omitempty" header:"LAST HOST"`
+	LastHostUUID              string `json:"lastHostUuid" yaml:"lastHostUuid" header:"LAST HOST UUID" resolve:"LastHostName"`
+	InstanceOfferingName      string `json:"instanceOfferingName,omitempty" yaml:"instanceOfferingName,omitempty" header:"INSTANCE OFFERING"`
+	InstanceOfferingUUID      string `json:"instanceOfferingUuid" yaml:"instanceOfferingUuid" header:"INSTANCE OFFERING UUID" resolve:"InstanceOfferingName"`
 	RootVolumeUuid            string `json:"rootVolumeUuid" yaml:"rootVolumeUuid" header:"ROOT VOLUME UUID"`
 	Platform                  string `json:"platform" yaml:"platform" header:"PLATFORM"`
-	DefaultL3NetworkUuid      string `json:"defaultL3NetworkUuid" yaml:"defaultL3NetworkUuid" header:"DEFAULT L3 NETWORK UUID"`
+	DefaultL3NetworkName      string `json:"defaultL3NetworkName,omitempty" yaml:"defaultL3NetworkName,omitempty" header:"DEFAULT L3 NETWORK"`
+	DefaultL3NetworkUuid      string `json:"defaultL3NetworkUuid" yaml:"defaultL3NetworkUuid" header:"DEFAULT L3 NETWORK UUID" resolve:"DefaultL3NetworkName"`
 	Type                      string `json:"type" yaml:"type" header:"TYPE"`
 	HypervisorType            string `json:"hypervisorType" yaml:"hypervisorType" header:"HYPERVISOR TYPE"`
 	MemorySize                string `json:"memorySize" yaml:"memorySize" header:"MEMORY SIZE"`
@@ -59,7 +68,7 @@ var virtualRoutersCmd = &cobra.Command{
 	Short: "List virtual routers",
 	Long:  `List all virtual routers in the ZStack cloud platform.`,
 	Args:  cobra.MaximumNArgs(1),
-	Run: func(cobraCmd *cobra.Command, args []string) {
+	Run: common.WatchRun(func(cobraCmd *cobra.Command, args []string) {
 
 		zsClient := client.GetClient()
 		if zsClient == nil {
@@ -73,11 +82,16 @@ var virtualRoutersCmd = &cobra.Command{
 			return
 		}
 
+		if common.RunAggregateQuery(cobraCmd, zsClient, queryParam, "v1/vm-instances/appliances/virtual-routers") {
+			return
+		}
+
 		virtualRouters, err := zsClient.QueryVirtualRouterVm(*queryParam)
 		if err != nil {
 			fmt.Printf("Error querying virtual routers: %s\n", err)
 			return
 		}
+		common.PrintReplyCount(cobraCmd, queryParam)
 
 		outputFormat, _ := cobraCmd.Flags().GetString("output")
 		format := utils.ParseFormat(outputFormat)
@@ -127,16 +141,46 @@ var virtualRoutersCmd = &cobra.Command{
 			formattedResults = append(formattedResults, formatted)
 		}
 
-		err = utils.PrintWithFields(formattedResults, format, fields)
+		names := common.NameResolverFromFlags(cobraCmd, zsClient)
+		for _, r := range formattedResults {
+			names.Add(client.KindL3Network, r.ManagementNetworkUuid)
+			names.Add(client.KindL3Network, r.DefaultRouteL3NetworkUuid)
+			names.Add(client.KindZone, r.ZoneUuid)
+			names.Add(client.KindCluster, r.ClusterUUID)
+			names.Add(client.KindImage, r.ImageUUID)
+			names.Add(client.KindHost, r.HostUuid)
+			names.Add(client.KindHost, r.LastHostUUID)
+			names.Add(client.KindInstanceOffering, r.InstanceOfferingUUID)
+			names.Add(client.KindL3Network, r.DefaultL3NetworkUuid)
+		}
+		if err := names.Fetch(); err != nil {
+			fmt.Printf("Error resolving names: %s\n", err)
+			return
+		}
+		for i := range formattedResults {
+			r := &formattedResults[i]
+			r.ManagementNetworkName = names.Name(r.ManagementNetworkUuid)
+			r.DefaultRouteL3NetworkName = names.Name(r.DefaultRouteL3NetworkUuid)
+			r.ZoneName = names.Name(r.ZoneUuid)
+			r.ClusterName = names.Name(r.ClusterUUID)
+			r.ImageName = names.Name(r.ImageUUID)
+			r.HostName = names.Name(r.HostUuid)
+			r.LastHostName = names.Name(r.LastHostUUID)
+			r.InstanceOfferingName = names.Name(r.InstanceOfferingUUID)
+			r.DefaultL3NetworkName = names.Name(r.DefaultL3NetworkUuid)
+		}
+
+		err = common.PrintResult(cobraCmd, formattedResults, format, fields)
 		if err != nil {
 			fmt.Printf("Error formatting output: %s\n", err)
 			return
 		}
 
-	},
+	}),
 }
 
 func init() {
 	GetCmd.AddCommand(virtualRoutersCmd)
 	common.AddQueryFlags(virtualRoutersCmd)
+	common.AddResolveNamesFlag(virtualRoutersCmd)
 }
@@ -0,0 +1,144 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recovery
+
+import (
+	"fmt"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+// DeletedImageRow is a recoverable or recovered image.
+type DeletedImageRow struct {
+	Name      string `json:"name"      yaml:"name"      header:"NAME"`
+	UUID      string `json:"uuid"      yaml:"uuid"      header:"UUID"`
+	Status    string `json:"status"    yaml:"status"    header:"STATUS"`
+	Format    string `json:"format"    yaml:"format"    header:"FORMAT"`
+	Size      string `json:"size"      yaml:"size"      header:"SIZE"`
+	DeletedAt string `json:"deletedAt" yaml:"deletedAt" header:"DELETED AT"`
+}
+
+var RecoverImagesCmd = &cobra.Command{
+	Use:     "image [name-or-uuid]",
+	Aliases: []string{"images"},
+	Short:   "Recover deleted images",
+	Long: `Recover images that were deleted but not expunged. Without an argument
+the recoverable images are listed.
+
+Examples:
+  # List deleted images
+  zstack-cli recover image
+
+  # Recover an image by name or UUID
+  zstack-cli recover image my-image`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRecoverImages(cmd, args)
+	},
+}
+
+func init() {
+	RecoverCmd.AddCommand(RecoverImagesCmd)
+}
+
+func runRecoverImages(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	if len(args) == 0 {
+		queryParam := param.NewQueryParam()
+		queryParam.AddQ(fmt.Sprintf("status=%s", types.ImageStatusDeleted))
+		queryParam.Sort("-lastOpDate")
+		images, err := cli.QueryImage(queryParam)
+		if err != nil {
+			return fmt.Errorf("failed to query images: %v", err)
+		}
+		if err := utils.PrintWithFields(deletedImageRows(images), format, fields); err != nil {
+			return fmt.Errorf("failed to format output: %s", err)
+		}
+		return nil
+	}
+
+	images, err := client.GetDeletedImagesByNameOrUUID(cli, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to query images: %v", err)
+	}
+	if len(images) == 0 {
+		fmt.Printf("No deleted images found with name or UUID '%s'.\n", args[0])
+		return nil
+	}
+
+	fmt.Fprintf(w, "Matched %d deleted image(s); they will be recovered:\n", len(images))
+	for _, img := range images {
+		fmt.Fprintf(w, "  - %s (%s) deleted at %s\n", img.Name, img.UUID, img.LastOpDate.Format(timeLayout))
+	}
+
+	calls := make([]utils.APICall, 0, len(images))
+	for _, img := range images {
+		calls = append(calls, utils.ActionCall("v1/images", img.UUID, utils.EmptyAction("recoverImage")))
+	}
+	if ok, err := confirmRecover(cmd, w, "images", calls); !ok {
+		return err
+	}
+
+	var recovered []sdkView.ImageView
+	failed := 0
+	for _, img := range images {
+		resp, err := client.RecoverImage(cli, img.UUID)
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "Failed to recover %s (%s): %v\n", img.Name, img.UUID, err)
+			continue
+		}
+		recovered = append(recovered, *resp)
+		fmt.Fprintf(w, "Recovered %s (%s), now %s\n", resp.Name, resp.UUID, resp.Status)
+	}
+
+	fmt.Fprintf(w, "\nSummary: %d recovered, %d failed\n", len(recovered), failed)
+	if len(recovered) == 0 {
+		return nil
+	}
+	if err := utils.PrintWithFields(deletedImageRows(recovered), format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return nil
+}
+
+func deletedImageRows(images []sdkView.ImageView) []DeletedImageRow {
+	rows := []DeletedImageRow{}
+	for _, img := range images {
+		rows = append(rows, DeletedImageRow{
+			Name:      img.Name,
+			UUID:      img.UUID,
+			Status:    img.Status,
+			Format:    img.Format,
+			Size:      utils.FormatMemorySize(img.Size),
+			DeletedAt: img.LastOpDate.Format(timeLayout),
+		})
+	}
+	return rows
+}
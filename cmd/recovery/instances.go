@@ -0,0 +1,138 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recovery
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+// DestroyedVmRow is a recoverable VM instance.
+type DestroyedVmRow struct {
+	Name        string `json:"name"        yaml:"name"        header:"NAME"`
+	UUID        string `json:"uuid"        yaml:"uuid"        header:"UUID"`
+	CPU         int    `json:"cpu"         yaml:"cpu"         header:"CPU"`
+	Memory      string `json:"memory"      yaml:"memory"      header:"MEMORY"`
+	DestroyedAt string `json:"destroyedAt" yaml:"destroyedAt" header:"DESTROYED AT"`
+}
+
+var RecoverInstancesCmd = &cobra.Command{
+	Use:     "instance [name-or-uuid]",
+	Aliases: []string{"instances"},
+	Short:   "Recover destroyed VM instances",
+	Long: `Recover VM instances that were deleted but not expunged. Recovered
+instances are left Stopped. Without an argument the recoverable instances
+are listed.
+
+Examples:
+  # List destroyed VM instances
+  zstack-cli recover instance
+
+  # Recover a VM instance by name or UUID
+  zstack-cli recover instance my-vm`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRecoverInstances(cmd, args)
+	},
+}
+
+func init() {
+	RecoverCmd.AddCommand(RecoverInstancesCmd)
+}
+
+func runRecoverInstances(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	if len(args) == 0 {
+		queryParam := param.NewQueryParam()
+		queryParam.AddQ(fmt.Sprintf("state=%s", types.VMStateDestroyed))
+		queryParam.Sort("-lastOpDate")
+		vms, err := cli.QueryVmInstance(queryParam)
+		if err != nil {
+			return fmt.Errorf("failed to query VMs: %v", err)
+		}
+		if err := utils.PrintWithFields(destroyedVmRows(vms), format, fields); err != nil {
+			return fmt.Errorf("failed to format output: %s", err)
+		}
+		return nil
+	}
+
+	vms, err := client.GetDestroyedVMsByNameOrUUID(cli, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to query VMs: %v", err)
+	}
+	if len(vms) == 0 {
+		fmt.Printf("No destroyed VMs found with name or UUID '%s'.\n", args[0])
+		return nil
+	}
+
+	fmt.Fprintf(w, "Matched %d destroyed VM(s); they will be recovered:\n", len(vms))
+	for _, vm := range vms {
+		fmt.Fprintf(w, "  - %s (%s) destroyed at %s\n", vm.Name, vm.UUID, vm.LastOpDate.Format(timeLayout))
+	}
+
+	calls := make([]utils.APICall, 0, len(vms))
+	for _, vm := range vms {
+		calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, utils.EmptyAction("recoverVmInstance")))
+	}
+	if ok, err := confirmRecover(cmd, w, "VM instances", calls); !ok {
+		return err
+	}
+
+	result := utils.NewBatchResult("recover", "recovered")
+	batchErr := common.RunBatch(common.BatchOptions{Parallel: 1, ContinueOnError: true}, w, result, vms, func(vm sdkView.VmInstanceInventoryView, out io.Writer) (*sdkView.VmInstanceInventoryView, error) {
+		resp, err := client.RecoverVmInstance(cli, vm.UUID)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(out, "Recovered %s (%s), now %s\n", resp.Name, resp.UUID, resp.State)
+		return resp, nil
+	})
+
+	if err := utils.PrintSummary(result, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return batchErr
+}
+
+func destroyedVmRows(vms []sdkView.VmInstanceInventoryView) []DestroyedVmRow {
+	rows := []DestroyedVmRow{}
+	for _, vm := range vms {
+		rows = append(rows, DestroyedVmRow{
+			Name:        vm.Name,
+			UUID:        vm.UUID,
+			CPU:         vm.CPUNum,
+			Memory:      utils.FormatMemorySize(vm.MemorySize),
+			DestroyedAt: vm.LastOpDate.Format(timeLayout),
+		})
+	}
+	return rows
+}
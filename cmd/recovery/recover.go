@@ -0,0 +1,56 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recovery
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// timeLayout is how destroy and delete times are shown.
+const timeLayout = "2006-01-02 15:04:05"
+
+var RecoverCmd = &cobra.Command{
+	Use:   "recover",
+	Short: "Recover deleted resources",
+	Long: `Recover destroyed VM instances and deleted images that have not been
+expunged yet. Run a subcommand without arguments to list what can be
+recovered.`,
+}
+
+func init() {
+	RecoverCmd.PersistentFlags().BoolP("yes", "y", false, "Automatic yes to prompts")
+	RecoverCmd.PersistentFlags().Bool("dry-run", false, "Print the API requests that would be sent, without sending them")
+	RecoverCmd.PersistentFlags().StringP("output", "o", "", "Output format: json, yaml, table, wide, or text")
+	RecoverCmd.PersistentFlags().StringSlice("fields", nil, "Custom fields to display in table output")
+}
+
+// confirmRecover handles --dry-run, which prints calls instead, and the
+// confirmation prompt and reports whether recovery should go ahead.
+func confirmRecover(cmd *cobra.Command, w io.Writer, kind string, calls []utils.APICall) (bool, error) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		outputFormat, _ := cmd.Flags().GetString("output")
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFormat)
+		return false, nil
+	}
+
+	return common.Confirm(cmd, w, common.Confirmation{Action: "recover", Kind: kind, Count: len(calls)})
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+const (
+	cloneStrategyInstantStart param.InstanceStrategy = "InstantStart"
+	cloneStrategyJustCreate   param.InstanceStrategy = "JustCreate"
+)
+
+var CloneInstanceCmd = &cobra.Command{
+	Use:   "clone <name-or-uuid>",
+	Short: "Clone a virtual machine instance (name or uuid).",
+	Long: `Clone a VM instance one or more times.
+
+Clone names come from --name-pattern, a Go template that can use
+{{.Index}} (starting at 1) and {{.Source}} (the name of the cloned VM).
+With --l3-network the clones are created stopped, moved to that network
+and then started unless --no-start is given.
+
+Examples:
+  zstack-cli instance clone my-vm
+  zstack-cli instance clone my-vm --count 5 --name-pattern web-{{.Index}}
+  zstack-cli instance clone my-vm --l3-network test-net --primary-storage ps-02`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCloneInstance(cmd, args[0])
+	},
+}
+
+func init() {
+	InstanceCmd.AddCommand(CloneInstanceCmd)
+	CloneInstanceCmd.Flags().Int("count", 1, "Number of clones to create")
+	CloneInstanceCmd.Flags().String("name-pattern", "{{.Source}}-clone-{{.Index}}", "Name template for the clones")
+	CloneInstanceCmd.Flags().Bool("full", false, "Also clone the data volumes attached to the VM")
+	CloneInstanceCmd.Flags().String("primary-storage", "", "Primary storage name or UUID for the cloned volumes")
+	CloneInstanceCmd.Flags().String("l3-network", "", "Put the clones on this L3 network instead of the source VM's networks")
+	CloneInstanceCmd.Flags().Bool("no-start", false, "Leave the clones stopped")
+}
+
+// cloneName is the data available to --name-pattern.
+type cloneName struct {
+	Index  int
+	Source string
+}
+
+func runCloneInstance(cmd *cobra.Command, identifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	count, _ := cmd.Flags().GetInt("count")
+	namePattern, _ := cmd.Flags().GetString("name-pattern")
+	full, _ := cmd.Flags().GetBool("full")
+	primaryStorage, _ := cmd.Flags().GetString("primary-storage")
+	l3Network, _ := cmd.Flags().GetString("l3-network")
+	noStart, _ := cmd.Flags().GetBool("no-start")
+
+	if count < 1 {
+		return fmt.Errorf("--count must be at least 1")
+	}
+
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(namePattern)
+	if err != nil {
+		return fmt.Errorf("invalid --name-pattern: %v", err)
+	}
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, identifier)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, count)
+	seen := make(map[string]bool)
+	for i := 1; i <= count; i++ {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, cloneName{Index: i, Source: vm.Name}); err != nil {
+			return fmt.Errorf("invalid --name-pattern: %v", err)
+		}
+		name := buf.String()
+		if seen[name] {
+			return fmt.Errorf("--name-pattern produces duplicate name '%s'; include {{.Index}}", name)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	p := param.CloneVmInstanceParam{
+		CloneVmInstance: param.CloneVmInstanceDetailParam{
+			Names:    names,
+			Strategy: cloneStrategyInstantStart,
+			Full:     &full,
+		},
+	}
+	if noStart || l3Network != "" {
+		p.CloneVmInstance.Strategy = cloneStrategyJustCreate
+	}
+
+	if primaryStorage != "" {
+		psUUID, err := client.GetPrimaryStorageUUIDByName(cli, primaryStorage)
+		if err != nil {
+			return err
+		}
+		p.CloneVmInstance.PrimaryStorageUuidForRootVolume = &psUUID
+		if full {
+			p.CloneVmInstance.PrimaryStorageUuidForDataVolume = &psUUID
+		}
+	}
+
+	l3UUID := ""
+	if l3Network != "" {
+		l3UUID, err = client.GetL3NetworkUUIDByName(cli, l3Network)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(w, "Will clone %s (%s) %d time(s):\n", vm.Name, vm.UUID, count)
+	for _, name := range names {
+		fmt.Fprintf(w, "  - %s\n", name)
+	}
+
+	if l3UUID != "" {
+		fmt.Fprintf(w, "After cloning, each clone is moved to L3 network %s (%s).\n", l3Network, l3UUID)
+	}
+
+	calls := []utils.APICall{utils.ActionCall("v1/vm-instances", vm.UUID, p)}
+	if l3UUID != "" {
+		// The clones do not exist yet; their NICs are on the same L3
+		// networks as those of the source VM.
+		for _, name := range names {
+			var nics []string
+			for _, nic := range vm.VMNics {
+				if nic.L3NetworkUUID != l3UUID {
+					nics = append(nics, fmt.Sprintf("<%s-nic-uuid-on-%s>", name, nic.L3NetworkUUID))
+				}
+			}
+			calls = append(calls, l3MoveCalls(fmt.Sprintf("<%s-uuid>", name), l3UUID, nics, !noStart)...)
+		}
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	fmt.Fprintln(w, "Cloning, this may take a while...")
+	resp, err := cli.CloneVmInstance(vm.UUID, p)
+	if err != nil {
+		return fmt.Errorf("failed to clone VM: %v", err)
+	}
+
+	var clones []sdkView.VmInstanceInventoryView
+	for _, inv := range resp.Inventories {
+		if inv.Error != nil {
+			fmt.Fprintf(w, "Failed to create clone: %s\n", formatErrorCode(inv.Error))
+			continue
+		}
+		clones = append(clones, inv.Inventory)
+	}
+
+	notMoved := 0
+	if l3UUID != "" {
+		for i, clone := range clones {
+			moved, err := moveToL3Network(cli, clone, l3UUID, !noStart)
+			if err != nil {
+				fmt.Fprintf(w, "Failed to move %s (%s) to L3 network %s: %v\n", clone.Name, clone.UUID, l3Network, err)
+				notMoved++
+				continue
+			}
+			clones[i] = *moved
+		}
+	}
+
+	fmt.Fprintf(w, "Created %d of %d clone(s).\n", len(clones), count)
+	if len(clones) > 0 {
+		if err := utils.PrintVMs(clones, format, fields); err != nil {
+			return fmt.Errorf("failed to format output: %s", err)
+		}
+	}
+	if len(clones) < count {
+		return fmt.Errorf("created %d of %d clones", len(clones), count)
+	}
+	if notMoved > 0 {
+		return fmt.Errorf("failed to move %d of %d clones to L3 network %s", notMoved, count, l3Network)
+	}
+	return nil
+}
+
+// l3MoveCalls are the requests moveToL3Network sends for the VM vmUUID
+// whose NICs nicUUIDs are on other networks.
+func l3MoveCalls(vmUUID, l3UUID string, nicUUIDs []string, start bool) []utils.APICall {
+	calls := []utils.APICall{
+		utils.PostCall(fmt.Sprintf("v1/vm-instances/%s/l3-networks/%s", vmUUID, l3UUID), param.AttachL3NetworkToVmParam{}),
+	}
+	for _, nicUUID := range nicUUIDs {
+		calls = append(calls, utils.DeletePathCall(fmt.Sprintf("v1/vm-instances/nics/%s", nicUUID)))
+	}
+	if start {
+		calls = append(calls, utils.ActionCall("v1/vm-instances", vmUUID, utils.EmptyAction("startVmInstance")))
+	}
+	return calls
+}
+
+// moveToL3Network attaches the stopped vm to l3UUID, detaches its other
+// NICs and optionally starts it.
+func moveToL3Network(cli *sdkClient.ZSClient, vm sdkView.VmInstanceInventoryView, l3UUID string, start bool) (*sdkView.VmInstanceInventoryView, error) {
+	updated, err := cli.AttachL3NetworkToVm(l3UUID, vm.UUID, param.AttachL3NetworkToVmParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nic := range vm.VMNics {
+		if nic.L3NetworkUUID == l3UUID {
+			continue
+		}
+		updated, err = cli.DetachL3NetworkFromVm(nic.UUID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if start {
+		updated, err = cli.StartVmInstance(vm.UUID, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+func formatErrorCode(e *sdkView.ErrorCodeView) string {
+	if e.Details != "" {
+		return fmt.Sprintf("[%s] %s: %s", e.Code, e.Description, e.Details)
+	}
+	return fmt.Sprintf("[%s] %s", e.Code, e.Description)
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/console"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+var ConsoleInstanceCmd = &cobra.Command{
+	Use:   "console <name-or-uuid>",
+	Short: "Open the graphical console of a virtual machine instance.",
+	Long: `Show how to reach the VNC/SPICE console of a running VM instance, or with
+--proxy serve it on a local port so that a desktop viewer can connect.
+
+Without --proxy the direct host address and a one-time websocket URL of the
+console proxy are printed. The websocket URL works with noVNC or websocat
+and expires after first use. If the console has a password it is only
+shown with --show-password, and never as part of the structured output.
+
+With --proxy every connection to --listen gets its own console session
+through the console proxy, so only the management network needs to be
+reachable. Press Ctrl-C to stop.
+
+Examples:
+  zstack-cli instance console my-vm
+  zstack-cli instance console my-vm --proxy
+  zstack-cli instance console my-vm --proxy --listen localhost:5901
+  zstack-cli instance console my-vm --show-password`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConsoleInstance(cmd, args[0])
+	},
+}
+
+func init() {
+	InstanceCmd.AddCommand(ConsoleInstanceCmd)
+	ConsoleInstanceCmd.Flags().Bool("proxy", false, "Serve the console on a local port for a VNC/SPICE viewer")
+	ConsoleInstanceCmd.Flags().String("listen", "localhost:5900", "Local address of the console proxy")
+	ConsoleInstanceCmd.Flags().Bool("insecure", false, "Skip TLS certificate verification of the console proxy")
+	ConsoleInstanceCmd.Flags().Bool("show-password", false, "Print the console password")
+}
+
+// ConsoleInfo describes how to reach the console of a VM.
+type ConsoleInfo struct {
+	Name         string `json:"name"               yaml:"name"               header:"NAME"`
+	UUID         string `json:"uuid"               yaml:"uuid"               header:"UUID"`
+	Protocol     string `json:"protocol"           yaml:"protocol"           header:"PROTOCOL"`
+	Address      string `json:"address,omitempty"  yaml:"address,omitempty"  header:"ADDRESS"`
+	WebsocketURL string `json:"websocketUrl"       yaml:"websocketUrl"       header:"WEBSOCKET URL"`
+}
+
+func runConsoleInstance(cmd *cobra.Command, identifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	proxy, _ := cmd.Flags().GetBool("proxy")
+	listen, _ := cmd.Flags().GetString("listen")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	showPassword, _ := cmd.Flags().GetBool("show-password")
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, identifier)
+	if err != nil {
+		return err
+	}
+	if vm.State != types.VMStateRunning {
+		return fmt.Errorf("%s (%s) is %s; the console is only available while it is Running", vm.Name, vm.UUID, vm.State)
+	}
+
+	address, err := cli.GetVmConsoleAddress(vm.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to get console address: %v", err)
+	}
+	// The password is optional; a VM without one returns an error here.
+	password, _ := cli.GetInstanceConsolePassword(vm.UUID)
+
+	if proxy {
+		return serveConsoleProxy(cli, vm.UUID, vm.Name, address.Protocol, password, showPassword, listen, insecure)
+	}
+
+	wsURL, err := requestConsoleURL(cli, vm.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to request console access: %v", err)
+	}
+
+	info := ConsoleInfo{
+		Name:         vm.Name,
+		UUID:         vm.UUID,
+		Protocol:     address.Protocol,
+		WebsocketURL: wsURL,
+	}
+	if address.HostIp != "" && address.Port != "" {
+		info.Address = fmt.Sprintf("%s://%s", consoleScheme(address.Protocol), net.JoinHostPort(address.HostIp, address.Port))
+	}
+
+	if utils.IsStructuredFormat(format) {
+		if err := utils.PrintWithFields([]ConsoleInfo{info}, format, fields); err != nil {
+			return fmt.Errorf("failed to format output: %s", err)
+		}
+		printConsolePassword(w, password, showPassword)
+		return nil
+	}
+
+	fmt.Fprintf(w, "Console of %s (%s), protocol %s\n", vm.Name, vm.UUID, info.Protocol)
+	if info.Address != "" {
+		fmt.Fprintf(w, "  Direct:    %s (requires access to the host network)\n", info.Address)
+	}
+	fmt.Fprintf(w, "  Websocket: %s (single use)\n", info.WebsocketURL)
+	printConsolePassword(w, password, showPassword)
+	fmt.Fprintf(w, "Run 'zstack-cli instance console %s --proxy' to connect a local viewer through the console proxy.\n", vm.UUID)
+	return nil
+}
+
+// serveConsoleProxy blocks serving the console of a VM on listen until
+// interrupted.
+func serveConsoleProxy(cli *sdkClient.ZSClient, vmUUID, vmName, protocol, password string, showPassword bool, listen string, insecure bool) error {
+	l, err := net.Listen("tcp", listen)
+	if err != nil {
+		return err
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	go func() {
+		<-interrupt
+		l.Close()
+	}()
+
+	fmt.Printf("Serving the console of %s (%s) on %s\n", vmName, vmUUID, l.Addr())
+	fmt.Printf("Connect with: %s://%s\n", consoleScheme(protocol), l.Addr())
+	printConsolePassword(os.Stdout, password, showPassword)
+	fmt.Println("Press Ctrl-C to stop.")
+
+	p := &console.Proxy{
+		Session: func() (string, error) {
+			return requestConsoleURL(cli, vmUUID)
+		},
+		Insecure: insecure,
+		Logf: func(format string, args ...interface{}) {
+			fmt.Printf(format, args...)
+		},
+	}
+	if err := p.Serve(l); err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
+		return err
+	}
+	fmt.Println("Console proxy stopped.")
+	return nil
+}
+
+// printConsolePassword writes the console password to w, or only notes that
+// there is one unless show is set.
+func printConsolePassword(w io.Writer, password string, show bool) {
+	switch {
+	case password == "":
+	case show:
+		fmt.Fprintf(w, "  Password:  %s\n", password)
+	default:
+		fmt.Fprintln(w, "  Password:  set (use --show-password to print it)")
+	}
+}
+
+func requestConsoleURL(cli *sdkClient.ZSClient, vmUUID string) (string, error) {
+	inv, err := cli.RequestConsoleAccess(param.RequestConsoleAccessParam{
+		Params: param.RequestConsoleAccessDetailParam{VMInstanceUUID: vmUUID},
+	})
+	if err != nil {
+		return "", err
+	}
+	return console.WebsocketURL(inv), nil
+}
+
+// consoleScheme maps the console protocol to the URL scheme viewers accept;
+// vncAndSpice consoles are reached over VNC.
+func consoleScheme(protocol string) string {
+	if strings.EqualFold(protocol, "spice") {
+		return "spice"
+	}
+	return "vnc"
+}
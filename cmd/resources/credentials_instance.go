@@ -0,0 +1,207 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+var SetPasswordCmd = &cobra.Command{
+	Use:   "set-password <name-or-uuid>",
+	Short: "Change the password of a guest OS account.",
+	Long: `Change the password of an account inside a running VM instance. The
+guest must run the QEMU guest agent. The new password is prompted for, or
+read from stdin when it is not a terminal.
+
+Examples:
+  zstack-cli instance set-password my-vm
+  zstack-cli instance set-password my-vm --user admin
+  echo "$NEW_PASSWORD" | zstack-cli instance set-password my-vm -y`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetPassword(cmd, args[0])
+	},
+}
+
+var SetSSHKeyCmd = &cobra.Command{
+	Use:   "set-ssh-key <name-or-uuid>",
+	Short: "Inject an SSH public key into a virtual machine instance.",
+	Long: `Set the SSH public key that cloud-init installs for the default user of
+a VM instance, or remove it with --remove. The guest picks up the key at its
+next boot.
+
+Examples:
+  zstack-cli instance set-ssh-key my-vm --key-file ~/.ssh/id_ed25519.pub
+  zstack-cli instance set-ssh-key my-vm --remove`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetSSHKey(cmd, args[0])
+	},
+}
+
+func init() {
+	InstanceCmd.AddCommand(SetPasswordCmd)
+	InstanceCmd.AddCommand(SetSSHKeyCmd)
+
+	SetPasswordCmd.Flags().String("user", "root", "Guest account whose password is changed")
+
+	SetSSHKeyCmd.Flags().String("key-file", "", "Path of the SSH public key file")
+	SetSSHKeyCmd.Flags().Bool("remove", false, "Remove the SSH key instead of setting one")
+}
+
+func runSetPassword(cmd *cobra.Command, identifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	user, _ := cmd.Flags().GetString("user")
+	if user == "" {
+		return fmt.Errorf("--user cannot be empty")
+	}
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, identifier)
+	if err != nil {
+		return err
+	}
+	if vm.State != types.VMStateRunning {
+		return fmt.Errorf("%s (%s) is %s; passwords can only be changed while it is Running", vm.Name, vm.UUID, vm.State)
+	}
+
+	fmt.Fprintf(w, "Will change the password of '%s' on %s (%s)\n", user, vm.Name, vm.UUID)
+
+	// The password is read after confirmation, so the dry run masks it.
+	calls := []utils.APICall{
+		utils.ActionCall("v1/vm-instances", vm.UUID, param.UpdateVmInstanceChangePwdParam{
+			UUID:             vm.UUID,
+			ChangeVmPassword: param.ChangeVmPasswordParam{Account: user, Password: "********"},
+		}),
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	password, err := utils.ReadNewPassword(fmt.Sprintf("New password for %s: ", user))
+	if err != nil {
+		return fmt.Errorf("failed to read password: %v", err)
+	}
+	if password == "" {
+		return fmt.Errorf("the password cannot be empty")
+	}
+
+	err = cli.ChangeVmPassword(param.UpdateVmInstanceChangePwdParam{
+		UUID:             vm.UUID,
+		ChangeVmPassword: param.ChangeVmPasswordParam{Account: user, Password: password},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to change password: %v", err)
+	}
+	fmt.Fprintf(w, "Changed the password of '%s' on %s (%s)\n", user, vm.Name, vm.UUID)
+
+	if utils.IsStructuredFormat(format) {
+		if err := utils.PrintVMs([]sdkView.VmInstanceInventoryView{*vm}, format, fields); err != nil {
+			return fmt.Errorf("failed to format output: %s", err)
+		}
+	}
+	return nil
+}
+
+func runSetSSHKey(cmd *cobra.Command, identifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	keyFile, _ := cmd.Flags().GetString("key-file")
+	remove, _ := cmd.Flags().GetBool("remove")
+
+	if keyFile == "" && !remove {
+		return fmt.Errorf("specify --key-file or --remove")
+	}
+	if keyFile != "" && remove {
+		return fmt.Errorf("--key-file and --remove cannot be used together")
+	}
+
+	var key string
+	if keyFile != "" {
+		var err error
+		key, err = utils.ReadSSHPublicKey(keyFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, identifier)
+	if err != nil {
+		return err
+	}
+
+	if remove {
+		fmt.Fprintf(w, "Will remove the SSH key of %s (%s)\n", vm.Name, vm.UUID)
+	} else {
+		fmt.Fprintf(w, "Will set the SSH key of %s (%s) to %s\n", vm.Name, vm.UUID, keyFile)
+	}
+	if vm.State == types.VMStateRunning {
+		fmt.Fprintln(w, "The guest picks up the change at its next boot.")
+	}
+
+	var call utils.APICall
+	if remove {
+		call = utils.DeletePathCall(fmt.Sprintf("v1/vm-instances/%s/ssh-keys?mode=%s", vm.UUID, param.DeleteModePermissive))
+	} else {
+		call = utils.ActionCall("v1/vm-instances", vm.UUID, client.SetVmSshKeyParams(key))
+	}
+	if ok, err := confirmInstanceChange(cmd, w, []utils.APICall{call}); !ok {
+		return err
+	}
+
+	if remove {
+		err = cli.DeleteVmSshKey(vm.UUID, param.DeleteModePermissive)
+	} else {
+		err = client.SetVmSshKey(cli, vm.UUID, key)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set SSH key: %v", err)
+	}
+	if remove {
+		fmt.Fprintf(w, "Removed the SSH key of %s (%s)\n", vm.Name, vm.UUID)
+	} else {
+		fmt.Fprintf(w, "Set the SSH key of %s (%s)\n", vm.Name, vm.UUID)
+	}
+
+	if utils.IsStructuredFormat(format) {
+		if err := utils.PrintVMs([]sdkView.VmInstanceInventoryView{*vm}, format, fields); err != nil {
+			return fmt.Errorf("failed to format output: %s", err)
+		}
+	}
+	return nil
+}
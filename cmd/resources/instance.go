@@ -1,7 +1,15 @@
 package resources
 
 import (
+	"fmt"
+	"io"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
 )
 
 var InstanceCmd = &cobra.Command{
@@ -16,3 +24,30 @@ func init() {
 	InstanceCmd.PersistentFlags().StringP("output", "o", "", "Output format: json, yaml, table, wide, or name")
 	InstanceCmd.PersistentFlags().StringSlice("fields", nil, "Custom fields to display in table output")
 }
+
+// confirmInstanceChange handles --dry-run, which prints calls instead, and
+// the confirmation prompt and reports whether the change should go ahead.
+func confirmInstanceChange(cmd *cobra.Command, w io.Writer, calls []utils.APICall) (bool, error) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		outputFormat, _ := cmd.Flags().GetString("output")
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFormat)
+		return false, nil
+	}
+
+	return common.Confirm(cmd, w, common.Confirmation{Action: "change", Kind: "VM instance", Count: 1})
+}
+
+// waitForVM waits, when --wait is set, until vm reaches state and returns
+// its refreshed inventory.
+func waitForVM(waiter *common.Waiter, cli *sdkClient.ZSClient, vm sdkView.VmInstanceInventoryView, state string) (*sdkView.VmInstanceInventoryView, error) {
+	if !waiter.Enabled {
+		return &vm, nil
+	}
+	name := fmt.Sprintf("%s (%s)", vm.Name, vm.UUID)
+	if err := waiter.Until(name, common.PollVMState(cli, vm.UUID), []string{state}, []string{types.VMStateDestroyed}); err != nil {
+		return nil, err
+	}
+	return cli.GetVmInstance(vm.UUID)
+}
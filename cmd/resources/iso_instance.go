@@ -0,0 +1,277 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+var AttachISOCmd = &cobra.Command{
+	Use:   "attach-iso <vm-name-or-uuid> <iso-name-or-uuid>",
+	Short: "Insert an ISO image into a CD-ROM of a virtual machine instance.",
+	Long: `Insert an ISO image into a CD-ROM of a VM instance. The image must have
+media type ISO. Without --cdrom the first empty CD-ROM is used; --cdrom takes
+a CD-ROM UUID or device ID as shown by 'zstack-cli get cdroms'.
+
+Examples:
+  zstack-cli instance attach-iso my-vm virtio-win
+  zstack-cli instance attach-iso my-vm centos-8 --cdrom 1`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAttachISO(cmd, args[0], args[1])
+	},
+}
+
+var DetachISOCmd = &cobra.Command{
+	Use:   "detach-iso <vm-name-or-uuid> [iso-name-or-uuid]",
+	Short: "Eject an ISO image from a virtual machine instance.",
+	Long: `Eject an ISO image from a VM instance. The ISO may be omitted when only
+one ISO is inserted.
+
+Examples:
+  zstack-cli instance detach-iso my-vm
+  zstack-cli instance detach-iso my-vm virtio-win`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		isoIdentifier := ""
+		if len(args) == 2 {
+			isoIdentifier = args[1]
+		}
+		return runDetachISO(cmd, args[0], isoIdentifier)
+	},
+}
+
+func init() {
+	InstanceCmd.AddCommand(AttachISOCmd)
+	InstanceCmd.AddCommand(DetachISOCmd)
+
+	AttachISOCmd.Flags().String("cdrom", "", "CD-ROM UUID or device ID to insert the ISO into")
+}
+
+func runAttachISO(cmd *cobra.Command, vmIdentifier, isoIdentifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	cdromFlag, _ := cmd.Flags().GetString("cdrom")
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, vmIdentifier)
+	if err != nil {
+		return err
+	}
+
+	iso, err := client.GetISOByNameOrUUID(cli, isoIdentifier)
+	if err != nil {
+		return err
+	}
+
+	cdroms, err := getVmCdRoms(cli, vm.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to query CD-ROMs: %v", err)
+	}
+	for _, cdrom := range cdroms {
+		if cdrom.IsoUuid == iso.UUID {
+			fmt.Fprintf(w, "ISO %s (%s) is already inserted in CD-ROM %s of %s (%s).\n", iso.Name, iso.UUID, cdrom.UUID, vm.Name, vm.UUID)
+			return nil
+		}
+	}
+
+	cdrom, err := pickCdRom(cdroms, cdromFlag)
+	if err != nil {
+		return err
+	}
+
+	// The candidate list accounts for backup storage reachability and
+	// hypervisor compatibility, which the attach call reports less clearly.
+	candidateParam := param.NewQueryParam()
+	candidates, err := cli.GetCandidateIsoForAttachingVm(vm.UUID, &candidateParam)
+	if err != nil {
+		return fmt.Errorf("failed to get candidate ISOs: %v", err)
+	}
+	if !hasImage(candidates, iso.UUID) {
+		return fmt.Errorf("ISO %s (%s) cannot be attached to %s (%s); it is not reachable from the VM's zone", iso.Name, iso.UUID, vm.Name, vm.UUID)
+	}
+
+	fmt.Fprintf(w, "Will insert ISO %s (%s) into CD-ROM %s (device %v) of %s (%s)\n", iso.Name, iso.UUID, cdrom.UUID, cdrom.DeviceId, vm.Name, vm.UUID)
+
+	calls := []utils.APICall{
+		utils.PostCall(fmt.Sprintf("v1/vm-instances/%s/iso/%s", vm.UUID, iso.UUID),
+			param.BaseParam{SystemTags: []string{fmt.Sprintf("cdromUuid::%s", cdrom.UUID)}}),
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	resp, err := cli.AttachIsoToVmInstance(iso.UUID, vm.UUID, cdrom.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to attach ISO: %v", err)
+	}
+	fmt.Fprintf(w, "Attached ISO %s (%s) to %s (%s)\n", iso.Name, iso.UUID, resp.Name, resp.UUID)
+
+	return printVmCdRoms(cli, vm.UUID, format, fields)
+}
+
+func runDetachISO(cmd *cobra.Command, vmIdentifier, isoIdentifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, vmIdentifier)
+	if err != nil {
+		return err
+	}
+
+	cdroms, err := getVmCdRoms(cli, vm.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to query CD-ROMs: %v", err)
+	}
+
+	var inserted []sdkView.VMCDRomView
+	for _, cdrom := range cdroms {
+		if cdrom.IsoUuid != "" {
+			inserted = append(inserted, cdrom)
+		}
+	}
+	if len(inserted) == 0 {
+		fmt.Fprintf(w, "%s (%s) has no ISO inserted.\n", vm.Name, vm.UUID)
+		return nil
+	}
+
+	var isoUUID string
+	if isoIdentifier == "" {
+		if len(inserted) > 1 {
+			return fmt.Errorf("%s (%s) has %d ISOs inserted; specify which one to detach", vm.Name, vm.UUID, len(inserted))
+		}
+		isoUUID = inserted[0].IsoUuid
+	} else {
+		for _, cdrom := range inserted {
+			if cdrom.IsoUuid == isoIdentifier {
+				isoUUID = cdrom.IsoUuid
+			}
+		}
+		if isoUUID == "" {
+			iso, err := client.GetISOByNameOrUUID(cli, isoIdentifier)
+			if err != nil {
+				return err
+			}
+			for _, cdrom := range inserted {
+				if cdrom.IsoUuid == iso.UUID {
+					isoUUID = iso.UUID
+				}
+			}
+			if isoUUID == "" {
+				return fmt.Errorf("ISO %s (%s) is not inserted in %s (%s)", iso.Name, iso.UUID, vm.Name, vm.UUID)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "Will eject ISO %s from %s (%s)\n", isoUUID, vm.Name, vm.UUID)
+
+	calls := []utils.APICall{
+		utils.DeletePathCall(fmt.Sprintf("v1/vm-instances/%s/iso?isoUuid=%s&deleteMode=%s", vm.UUID, isoUUID, param.DeleteModePermissive)),
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	resp, err := cli.DetachIsoFromVmInstance(vm.UUID, isoUUID)
+	if err != nil {
+		return fmt.Errorf("failed to detach ISO: %v", err)
+	}
+	fmt.Fprintf(w, "Detached ISO %s from %s (%s)\n", isoUUID, resp.Name, resp.UUID)
+
+	return printVmCdRoms(cli, vm.UUID, format, fields)
+}
+
+// getVmCdRoms returns the CD-ROMs of a VM ordered by device ID.
+func getVmCdRoms(cli *sdkClient.ZSClient, vmUUID string) ([]sdkView.VMCDRomView, error) {
+	queryParam := param.NewQueryParam()
+	queryParam.AddQ(fmt.Sprintf("vmInstanceUuid=%s", vmUUID))
+	cdroms, err := cli.QueryVmCdRom(queryParam)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(cdroms, func(i, j int) bool { return cdroms[i].DeviceId < cdroms[j].DeviceId })
+	return cdroms, nil
+}
+
+// pickCdRom returns the CD-ROM named by identifier (a UUID or device ID),
+// or the first empty CD-ROM when identifier is empty.
+func pickCdRom(cdroms []sdkView.VMCDRomView, identifier string) (*sdkView.VMCDRomView, error) {
+	if len(cdroms) == 0 {
+		return nil, fmt.Errorf("the VM has no CD-ROM")
+	}
+
+	if identifier == "" {
+		for i, cdrom := range cdroms {
+			if cdrom.IsoUuid == "" {
+				return &cdroms[i], nil
+			}
+		}
+		return nil, fmt.Errorf("every CD-ROM of the VM already holds an ISO; detach one first or pass --cdrom")
+	}
+
+	deviceID, convErr := strconv.Atoi(identifier)
+	for i, cdrom := range cdroms {
+		if cdrom.UUID == identifier || (convErr == nil && int(cdrom.DeviceId) == deviceID) {
+			if cdrom.IsoUuid != "" {
+				return nil, fmt.Errorf("CD-ROM %s already holds ISO %s; detach it first", cdrom.UUID, cdrom.IsoUuid)
+			}
+			return &cdroms[i], nil
+		}
+	}
+	return nil, fmt.Errorf("the VM has no CD-ROM matching '%s'", identifier)
+}
+
+func printVmCdRoms(cli *sdkClient.ZSClient, vmUUID string, format utils.OutputFormat, fields []string) error {
+	cdroms, err := getVmCdRoms(cli, vmUUID)
+	if err != nil {
+		return fmt.Errorf("failed to query CD-ROMs: %v", err)
+	}
+	if err := utils.PrintCdRoms(cdroms, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return nil
+}
+
+func hasImage(images []sdkView.ImageView, imageUUID string) bool {
+	for _, img := range images {
+		if img.UUID == imageUUID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,295 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+const migrateStrategyAutoConverge = "auto-converge"
+
+var MigrateInstanceCmd = &cobra.Command{
+	Use:   "migrate [name-or-uuid]",
+	Short: "Live migrate a virtual machine instance (name or uuid).",
+	Long: `Live migrate running VM instances to another host.
+
+Without --host or --auto the candidate hosts of each matched VM are listed
+and nothing is migrated. With --from-host every running VM on that host is
+migrated, which is useful to evacuate a host before maintenance; a name given
+with --from-host narrows the VMs on that host. Names are matched exactly
+unless --match substring is given. You will be prompted for confirmation
+unless -y/--yes is provided.
+
+Examples:
+  zstack-cli instance migrate my-vm
+  zstack-cli instance migrate my-vm --host host-02
+  zstack-cli instance migrate --from-host host-01 --auto --parallel 4
+  zstack-cli instance migrate web- --match substring --from-host host-01 --auto`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		identifier := ""
+		if len(args) == 1 {
+			identifier = args[0]
+		}
+		return runMigrateInstance(cmd, identifier)
+	},
+}
+
+func init() {
+	InstanceCmd.AddCommand(MigrateInstanceCmd)
+	MigrateInstanceCmd.Flags().String("host", "", "Target host name or UUID")
+	MigrateInstanceCmd.Flags().Bool("auto", false, "Let the cloud choose the target host")
+	MigrateInstanceCmd.Flags().String("from-host", "", "Migrate every running VM on this host (name or UUID)")
+	MigrateInstanceCmd.Flags().String("strategy", "", "Migration strategy: auto-converge throttles busy VMs so that migration can finish")
+	common.AddMatchFlag(MigrateInstanceCmd)
+	common.AddBatchFlags(MigrateInstanceCmd)
+}
+
+func runMigrateInstance(cmd *cobra.Command, identifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	targetHost, _ := cmd.Flags().GetString("host")
+	auto, _ := cmd.Flags().GetBool("auto")
+	fromHost, _ := cmd.Flags().GetString("from-host")
+	strategy, _ := cmd.Flags().GetString("strategy")
+
+	if identifier == "" && fromHost == "" {
+		return fmt.Errorf("specify a VM name or UUID, or --from-host")
+	}
+	if targetHost != "" && auto {
+		return fmt.Errorf("--host and --auto cannot be used together")
+	}
+	if strategy != "" && strategy != migrateStrategyAutoConverge {
+		return fmt.Errorf("unsupported strategy '%s', must be '%s'", strategy, migrateStrategyAutoConverge)
+	}
+	opts, err := common.BatchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	var vms []sdkView.VmInstanceInventoryView
+	if fromHost != "" {
+		vms, err = getVMsOnHost(cmd, cli, fromHost, identifier)
+	} else {
+		vms, err = common.MatchVMs(cmd, cli, identifier, []string{fmt.Sprintf("state!=%s", types.VMStateDestroyed)})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query VMs: %v", err)
+	}
+	if len(vms) == 0 {
+		fmt.Println("No matching VMs found.")
+		return nil
+	}
+
+	targetHostUUID := ""
+	if targetHost != "" {
+		targetHostUUID, err = client.GetHostUUIDByName(cli, targetHost)
+		if err != nil {
+			return err
+		}
+	}
+
+	result := utils.NewBatchResult("migrate", "migrated")
+	var toMigrate, skipped []sdkView.VmInstanceInventoryView
+	for _, vm := range vms {
+		switch {
+		case vm.State != types.VMStateRunning:
+			result.Skip(vm, "not Running")
+			skipped = append(skipped, vm)
+		case targetHostUUID != "" && vm.HostUUID == targetHostUUID:
+			result.Skip(vm, "already on target host")
+			skipped = append(skipped, vm)
+		default:
+			toMigrate = append(toMigrate, vm)
+		}
+	}
+
+	if targetHostUUID == "" && !auto {
+		printMigrationCandidates(cli, toMigrate)
+		if len(toMigrate) > 0 {
+			fmt.Println("Specify --host <host> or --auto to migrate.")
+		}
+		return nil
+	}
+
+	if len(toMigrate) == 0 {
+		fmt.Fprintln(w, "No matched VMs can be migrated.")
+		if len(skipped) > 0 {
+			fmt.Fprintln(w, "Matched but skipped:")
+			for _, s := range result.Skipped {
+				fmt.Fprintf(w, "  - %s (%s) state=%s: %s\n", s.Name, s.UUID, s.State, s.Reason)
+			}
+		}
+		if utils.IsStructuredFormat(format) {
+			if err := utils.PrintSummary(result, format, fields); err != nil {
+				return fmt.Errorf("failed to format output: %s", err)
+			}
+		}
+		return nil
+	}
+
+	// The target must be a migration candidate of every VM sent to it.
+	if targetHostUUID != "" {
+		var eligible []sdkView.VmInstanceInventoryView
+		for _, vm := range toMigrate {
+			candidates, err := cli.GetVmMigrationCandidateHosts(vm.UUID)
+			if err != nil {
+				result.Fail(vm, err)
+				fmt.Fprintf(w, "Failed to get candidate hosts of %s (%s): %v\n", vm.Name, vm.UUID, err)
+				continue
+			}
+			if !hasHost(candidates, targetHostUUID) {
+				result.Skip(vm, fmt.Sprintf("host %s is not a migration candidate", targetHost))
+				skipped = append(skipped, vm)
+				continue
+			}
+			eligible = append(eligible, vm)
+		}
+		toMigrate = eligible
+		if len(toMigrate) == 0 {
+			fmt.Fprintf(w, "No matched VMs can be migrated to host '%s'.\n", targetHost)
+			if err := utils.PrintSummary(result, format, fields); err != nil {
+				return fmt.Errorf("failed to format output: %s", err)
+			}
+			return nil
+		}
+	}
+
+	destination := "a host chosen by the cloud"
+	if targetHostUUID != "" {
+		destination = fmt.Sprintf("host %s (%s)", targetHost, targetHostUUID)
+	}
+	fmt.Fprintf(w, "Matched %d VM(s); %d will be migrated to %s, %d will be skipped.\n", len(vms), len(toMigrate), destination, len(skipped))
+	fmt.Fprintln(w, "Will migrate:")
+	for _, s := range toMigrate {
+		fmt.Fprintf(w, "  - %s (%s) host=%s\n", s.Name, s.UUID, s.HostUUID)
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintln(w, "Skipped:")
+		for _, s := range result.Skipped {
+			fmt.Fprintf(w, "  - %s (%s) state=%s: %s\n", s.Name, s.UUID, s.State, s.Reason)
+		}
+	}
+
+	migrateBody := map[string]interface{}{
+		"migrateVm": map[string]interface{}{"hostUuid": targetHostUUID, "strategy": strategy},
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		calls := make([]utils.APICall, 0, len(toMigrate))
+		for _, vm := range toMigrate {
+			calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, migrateBody))
+		}
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFormat)
+		return nil
+	}
+
+	ok, err := common.Confirm(cmd, w, common.Confirmation{Action: "migrate", Kind: "VM instances", Count: len(toMigrate)})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	autoConverge := strategy == migrateStrategyAutoConverge
+
+	batchErr := common.RunBatch(opts, w, result, toMigrate, func(vm sdkView.VmInstanceInventoryView, out io.Writer) (*sdkView.VmInstanceInventoryView, error) {
+		resp, err := cli.LiveMigrateVM(vm.UUID, targetHostUUID, autoConverge)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(out, "Migrated %s (%s) to host %s\n", resp.Name, resp.UUID, resp.HostUUID)
+		return resp, nil
+	})
+
+	if err := utils.PrintSummary(result, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return batchErr
+}
+
+// getVMsOnHost returns the VMs running on host, optionally narrowed to those
+// matching identifier by UUID or, according to --match, by name.
+func getVMsOnHost(cmd *cobra.Command, cli *sdkClient.ZSClient, host, identifier string) ([]sdkView.VmInstanceInventoryView, error) {
+	hostUUID, err := client.GetHostUUIDByName(cli, host)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []string{fmt.Sprintf("hostUuid=%s", hostUUID), "type=UserVm"}
+	if identifier != "" {
+		return common.MatchVMs(cmd, cli, identifier, conditions)
+	}
+	queryParam := param.NewQueryParam()
+	for _, c := range conditions {
+		queryParam.AddQ(c)
+	}
+	return cli.QueryVmInstance(queryParam)
+}
+
+func printMigrationCandidates(cli *sdkClient.ZSClient, vms []sdkView.VmInstanceInventoryView) {
+	if len(vms) == 0 {
+		fmt.Println("No matched VMs are in 'Running' state to migrate.")
+		return
+	}
+
+	for _, vm := range vms {
+		hosts, err := cli.GetVmMigrationCandidateHosts(vm.UUID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting candidate hosts of %s (%s): %v\n", vm.Name, vm.UUID, err)
+			continue
+		}
+
+		fmt.Printf("Candidate hosts for %s (%s), currently on %s:\n", vm.Name, vm.UUID, vm.HostUUID)
+		if len(hosts) == 0 {
+			fmt.Println("  (none)")
+			continue
+		}
+		for _, h := range hosts {
+			fmt.Printf("  - %s (%s) %s available cpu=%d memory=%s\n", h.Name, h.UUID, h.ManagementIp,
+				h.AvailableCpuCapacity, utils.FormatMemorySize(h.AvailableMemoryCapacity))
+		}
+	}
+}
+
+func hasHost(hosts []sdkView.HostInventoryView, hostUUID string) bool {
+	for _, h := range hosts {
+		if h.UUID == hostUUID {
+			return true
+		}
+	}
+	return false
+}
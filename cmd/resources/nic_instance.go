@@ -0,0 +1,381 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+var AttachNicCmd = &cobra.Command{
+	Use:   "attach-nic <name-or-uuid>",
+	Short: "Attach a NIC on an L3 network to a virtual machine instance.",
+	Long: `Attach a new NIC on --l3-network to a VM instance, optionally with a
+fixed --ip.
+
+Examples:
+  zstack-cli instance attach-nic my-vm --l3-network vlan-100
+  zstack-cli instance attach-nic my-vm --l3-network vlan-100 --ip 10.0.0.5`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAttachNic(cmd, args[0])
+	},
+}
+
+var DetachNicCmd = &cobra.Command{
+	Use:   "detach-nic <vm-name-or-uuid> <nic>",
+	Short: "Detach a NIC from a virtual machine instance.",
+	Long: `Detach a NIC from a VM instance. The NIC is given by its UUID, IP or MAC
+address, or by the name or UUID of its L3 network.
+
+Examples:
+  zstack-cli instance detach-nic my-vm 10.0.0.5
+  zstack-cli instance detach-nic my-vm vlan-100`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDetachNic(cmd, args[0], args[1])
+	},
+}
+
+var SetDefaultNicCmd = &cobra.Command{
+	Use:   "set-default-nic <vm-name-or-uuid> <nic>",
+	Short: "Make a NIC the default NIC of a virtual machine instance.",
+	Long: `Make a NIC the default NIC of a VM instance; its L3 network provides the
+default route. The NIC is given by its UUID, IP or MAC address, or by the
+name or UUID of its L3 network.
+
+Examples:
+  zstack-cli instance set-default-nic my-vm vlan-200`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetDefaultNic(cmd, args[0], args[1])
+	},
+}
+
+var SetStaticIPCmd = &cobra.Command{
+	Use:   "set-static-ip <vm-name-or-uuid> <nic>",
+	Short: "Set or remove the static IP of a virtual machine NIC.",
+	Long: `Pin the IP address of a VM NIC with --ip, or release the pinned address
+with --remove. The NIC is given by its UUID, IP or MAC address, or by the
+name or UUID of its L3 network. A running VM picks up the new address after
+a reboot or a DHCP renewal.
+
+Examples:
+  zstack-cli instance set-static-ip my-vm vlan-100 --ip 10.0.0.8
+  zstack-cli instance set-static-ip my-vm 10.0.0.8 --remove`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetStaticIP(cmd, args[0], args[1])
+	},
+}
+
+func init() {
+	InstanceCmd.AddCommand(AttachNicCmd)
+	InstanceCmd.AddCommand(DetachNicCmd)
+	InstanceCmd.AddCommand(SetDefaultNicCmd)
+	InstanceCmd.AddCommand(SetStaticIPCmd)
+
+	AttachNicCmd.Flags().String("l3-network", "", "L3 network name or UUID (required)")
+	AttachNicCmd.Flags().String("ip", "", "Static IP address for the new NIC")
+	AttachNicCmd.MarkFlagRequired("l3-network")
+
+	SetStaticIPCmd.Flags().String("ip", "", "Static IP address to set")
+	SetStaticIPCmd.Flags().Bool("remove", false, "Remove the static IP instead of setting one")
+}
+
+func runAttachNic(cmd *cobra.Command, identifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	l3Network, _ := cmd.Flags().GetString("l3-network")
+	ip, _ := cmd.Flags().GetString("ip")
+
+	if ip != "" && net.ParseIP(ip) == nil {
+		return fmt.Errorf("invalid IP address '%s'", ip)
+	}
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, identifier)
+	if err != nil {
+		return err
+	}
+
+	l3UUID, err := client.GetL3NetworkUUIDByName(cli, l3Network)
+	if err != nil {
+		return err
+	}
+
+	for _, nic := range vm.VMNics {
+		if nic.L3NetworkUUID == l3UUID {
+			return fmt.Errorf("%s (%s) already has NIC %s (%s) on L3 network %s", vm.Name, vm.UUID, nic.UUID, nic.IP, l3Network)
+		}
+	}
+
+	if ip != "" {
+		check, err := cli.CheckIpAvailability(l3UUID, ip)
+		if err != nil {
+			return fmt.Errorf("failed to check IP availability: %v", err)
+		}
+		if !check.Available {
+			return fmt.Errorf("IP %s is not available on L3 network %s", ip, l3Network)
+		}
+	}
+
+	target := "an automatically assigned IP"
+	if ip != "" {
+		target = "IP " + ip
+	}
+	fmt.Fprintf(w, "Will attach a NIC on L3 network %s (%s) with %s to %s (%s)\n", l3Network, l3UUID, target, vm.Name, vm.UUID)
+
+	p := param.AttachL3NetworkToVmParam{Params: param.AttachL3NetworkToVmDetailParam{StaticIp: ip}}
+	calls := []utils.APICall{
+		utils.PostCall(fmt.Sprintf("v1/vm-instances/%s/l3-networks/%s", vm.UUID, l3UUID), p),
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	resp, err := cli.AttachL3NetworkToVm(l3UUID, vm.UUID, p)
+	if err != nil {
+		return fmt.Errorf("failed to attach NIC: %v", err)
+	}
+	fmt.Fprintf(w, "Attached a NIC on L3 network %s to %s (%s)\n", l3Network, resp.Name, resp.UUID)
+
+	if err := utils.PrintVmNics(*resp, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return nil
+}
+
+func runDetachNic(cmd *cobra.Command, vmIdentifier, nicIdentifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, vmIdentifier)
+	if err != nil {
+		return err
+	}
+
+	nic, err := findVmNic(cli, vm, nicIdentifier)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Will detach NIC %s (ip=%s mac=%s) from %s (%s)\n", nic.UUID, nic.IP, nic.Mac, vm.Name, vm.UUID)
+	if len(vm.VMNics) == 1 {
+		fmt.Fprintln(w, "Warning: this is the only NIC of the VM; it will lose network access.")
+	} else if nic.L3NetworkUUID == vm.DefaultL3NetworkUUID {
+		fmt.Fprintln(w, "Warning: this is the default NIC; another NIC will become the default.")
+	}
+
+	calls := []utils.APICall{
+		utils.DeletePathCall(fmt.Sprintf("v1/vm-instances/nics/%s", nic.UUID)),
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	resp, err := cli.DetachL3NetworkFromVm(nic.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to detach NIC: %v", err)
+	}
+	fmt.Fprintf(w, "Detached NIC %s from %s (%s)\n", nic.UUID, resp.Name, resp.UUID)
+
+	if err := utils.PrintVmNics(*resp, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return nil
+}
+
+func runSetDefaultNic(cmd *cobra.Command, vmIdentifier, nicIdentifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, vmIdentifier)
+	if err != nil {
+		return err
+	}
+
+	nic, err := findVmNic(cli, vm, nicIdentifier)
+	if err != nil {
+		return err
+	}
+
+	if nic.L3NetworkUUID == vm.DefaultL3NetworkUUID {
+		fmt.Fprintf(w, "NIC %s is already the default NIC of %s (%s).\n", nic.UUID, vm.Name, vm.UUID)
+		return nil
+	}
+
+	fmt.Fprintf(w, "Will make NIC %s (ip=%s, L3 network %s) the default NIC of %s (%s)\n", nic.UUID, nic.IP, nic.L3NetworkUUID, vm.Name, vm.UUID)
+
+	calls := []utils.APICall{
+		utils.ActionCall("v1/vm-instances", vm.UUID, client.SetVmDefaultL3NetworkParams(nic.L3NetworkUUID)),
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	resp, err := client.SetVmDefaultL3Network(cli, vm.UUID, nic.L3NetworkUUID)
+	if err != nil {
+		return fmt.Errorf("failed to set default NIC: %v", err)
+	}
+	fmt.Fprintf(w, "Set NIC %s as the default NIC of %s (%s)\n", nic.UUID, resp.Name, resp.UUID)
+
+	if err := utils.PrintVmNics(*resp, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return nil
+}
+
+func runSetStaticIP(cmd *cobra.Command, vmIdentifier, nicIdentifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	ip, _ := cmd.Flags().GetString("ip")
+	remove, _ := cmd.Flags().GetBool("remove")
+
+	if ip == "" && !remove {
+		return fmt.Errorf("specify --ip or --remove")
+	}
+	if ip != "" && remove {
+		return fmt.Errorf("--ip and --remove cannot be used together")
+	}
+	if ip != "" && net.ParseIP(ip) == nil {
+		return fmt.Errorf("invalid IP address '%s'", ip)
+	}
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, vmIdentifier)
+	if err != nil {
+		return err
+	}
+
+	nic, err := findVmNic(cli, vm, nicIdentifier)
+	if err != nil {
+		return err
+	}
+
+	if remove {
+		fmt.Fprintf(w, "Will remove the static IP of NIC %s (ip=%s) on %s (%s)\n", nic.UUID, nic.IP, vm.Name, vm.UUID)
+	} else {
+		if ip == nic.IP {
+			fmt.Fprintf(w, "NIC %s of %s (%s) already has IP %s.\n", nic.UUID, vm.Name, vm.UUID, ip)
+			return nil
+		}
+		check, err := cli.CheckIpAvailability(nic.L3NetworkUUID, ip)
+		if err != nil {
+			return fmt.Errorf("failed to check IP availability: %v", err)
+		}
+		if !check.Available {
+			return fmt.Errorf("IP %s is not available on L3 network %s", ip, nic.L3NetworkUUID)
+		}
+		fmt.Fprintf(w, "Will change the IP of NIC %s on %s (%s): %s -> %s\n", nic.UUID, vm.Name, vm.UUID, nic.IP, ip)
+		if vm.State != types.VMStateStopped {
+			fmt.Fprintln(w, "The guest picks up the new address after a reboot or a DHCP renewal.")
+		}
+	}
+
+	var call utils.APICall
+	if remove {
+		call = utils.DeletePathCall(fmt.Sprintf("v1/vm-instances/%s/static-ips?l3NetworkUuid=%s", vm.UUID, nic.L3NetworkUUID))
+	} else {
+		call = utils.ActionCall("v1/vm-instances", vm.UUID, client.SetVmStaticIpParams(nic.L3NetworkUUID, ip))
+	}
+	if ok, err := confirmInstanceChange(cmd, w, []utils.APICall{call}); !ok {
+		return err
+	}
+
+	if remove {
+		err = cli.DeleteVmStaticIp(vm.UUID, param.DeleteVmStaticIpParam{
+			Params: param.DeleteVmStaticIpDetailParam{L3NetworkUuid: nic.L3NetworkUUID},
+		})
+	} else {
+		err = client.SetVmStaticIp(cli, vm.UUID, nic.L3NetworkUUID, ip)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set static IP: %v", err)
+	}
+
+	resp, err := cli.GetVmInstance(vm.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to get VM: %v", err)
+	}
+	if remove {
+		fmt.Fprintf(w, "Removed the static IP of NIC %s on %s (%s)\n", nic.UUID, resp.Name, resp.UUID)
+	} else {
+		fmt.Fprintf(w, "Set the static IP of NIC %s on %s (%s) to %s\n", nic.UUID, resp.Name, resp.UUID, ip)
+	}
+
+	if err := utils.PrintVmNics(*resp, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return nil
+}
+
+// findVmNic returns the NIC of vm identified by its UUID, IP or MAC
+// address, or by the name or UUID of its L3 network.
+func findVmNic(cli *sdkClient.ZSClient, vm *sdkView.VmInstanceInventoryView, identifier string) (*sdkView.VmNicInventoryView, error) {
+	for i, nic := range vm.VMNics {
+		if nic.UUID == identifier || nic.IP == identifier || strings.EqualFold(nic.Mac, identifier) {
+			return &vm.VMNics[i], nil
+		}
+	}
+
+	l3UUID, err := client.GetL3NetworkUUIDByName(cli, identifier)
+	if err == nil {
+		for i, nic := range vm.VMNics {
+			if nic.L3NetworkUUID == l3UUID {
+				return &vm.VMNics[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%s (%s) has no NIC matching '%s'", vm.Name, vm.UUID, identifier)
+}
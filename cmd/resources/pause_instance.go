@@ -1,12 +1,11 @@
 package resources
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
+	"io"
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
 	"github.com/chijiajian/zstack-cli-go/pkg/types"
 	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
@@ -14,126 +13,121 @@ import (
 )
 
 var PauseInstanceCmd = &cobra.Command{
-	Use:   "pause <name-or-uuid>",
-	Short: "Pause a virtual machine instance (name or uuid).",
-	Long: `Pause a VM instance by name or UUID. 
-Supports batch pause if multiple matches are found. You will be prompted
-for confirmation unless -y/--yes is provided.
+	Use:   "pause [name-or-uuid...]",
+	Short: "Pause virtual machine instances (name or uuid).",
+	Long: `Pause VM instances by name or UUID, or every VM chosen by --selector,
+-q, --host, --cluster or --from-file. Names are matched exactly unless
+--match substring is given. You will be prompted for confirmation unless
+-y/--yes is provided.
 
 Example:
   zstack-cli instance pause my-vm`,
-	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		runPauseInstance(cmd, args[0])
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPauseInstance(cmd, args)
 	},
 }
 
 func init() {
 	InstanceCmd.AddCommand(PauseInstanceCmd)
+	common.AddBatchFlags(PauseInstanceCmd)
+	common.AddVMSelectorFlags(PauseInstanceCmd)
 }
 
-func runPauseInstance(cmd *cobra.Command, identifier string) {
+func runPauseInstance(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	opts, err := common.BatchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	cli := client.GetClient()
 	if cli == nil {
-		fmt.Println("Error: Not logged in. Please run 'zstack-cli login' first.")
-		return
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
 	}
 
-	vms, err := client.GetReadyVMsByNameOrUUID(cli, identifier)
+	vms, err := common.SelectVMs(cmd, cli, args, types.IsVMActive)
 	if err != nil {
-		fmt.Printf("Error querying VMs: %v\n", err)
-		return
+		return err
 	}
 	if len(vms) == 0 {
-		fmt.Printf("No VMs found with name or UUID '%s'.\n", identifier)
-		return
+		fmt.Println("No matching VMs found.")
+		return nil
 	}
 
+	result := utils.NewBatchResult("pause", "paused")
 	var toPause, skipped []sdkView.VmInstanceInventoryView
 	for _, vm := range vms {
 		if vm.State == types.VMStateRunning {
 			toPause = append(toPause, vm)
 		} else {
 			skipped = append(skipped, vm)
+			result.Skip(vm, "not Running")
 		}
 	}
 
 	if len(toPause) == 0 {
-		fmt.Println("No matched VMs are in 'Running' state to pause.")
+		fmt.Fprintln(w, "No matched VMs are in 'Running' state to pause.")
 		if len(skipped) > 0 {
-			fmt.Println("Matched but skipped (not Running):")
+			fmt.Fprintln(w, "Matched but skipped (not Running):")
 			for _, s := range skipped {
-				fmt.Printf("  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+				fmt.Fprintf(w, "  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+			}
+		}
+		if utils.IsStructuredFormat(format) {
+			if err := utils.PrintSummary(result, format, fields); err != nil {
+				return fmt.Errorf("failed to format output: %s", err)
 			}
 		}
-		return
+		return nil
 	}
 
-	fmt.Printf("Matched %d VM(s); %d will be paused, %d will be skipped.\n", len(vms), len(toPause), len(skipped))
-	fmt.Println("Will pause:")
+	fmt.Fprintf(w, "Matched %d VM(s); %d will be paused, %d will be skipped.\n", len(vms), len(toPause), len(skipped))
+	fmt.Fprintln(w, "Will pause:")
 	for _, s := range toPause {
-		fmt.Printf("  - %s (%s)\n", s.Name, s.UUID)
+		fmt.Fprintf(w, "  - %s (%s)\n", s.Name, s.UUID)
 	}
 	if len(skipped) > 0 {
-		fmt.Println("Skipped (not Running):")
+		fmt.Fprintln(w, "Skipped (not Running):")
 		for _, s := range skipped {
-			fmt.Printf("  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+			fmt.Fprintf(w, "  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
 		}
 	}
 
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	if dryRun {
-		fmt.Println("Dry-run: no API calls will be made.")
-		return
-	}
-
-	autoYes, _ := cmd.Flags().GetBool("yes")
-	if !autoYes {
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("Do you want to continue? [y/N]: ")
-		line, _ := reader.ReadString('\n')
-		line = strings.TrimSpace(strings.ToLower(line))
-		if line != "y" && line != "yes" {
-			fmt.Println("Aborted by user.")
-			return
+		calls := make([]utils.APICall, 0, len(toPause))
+		for _, vm := range toPause {
+			calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, utils.EmptyAction("pauseVmInstance")))
 		}
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFormat)
+		return nil
 	}
 
-	var successes []sdkView.VmInstanceInventoryView
-	var failures []struct {
-		VM  sdkView.VmInstanceInventoryView
-		Err error
+	ok, err := common.Confirm(cmd, w, common.Confirmation{Action: "pause", Kind: "VM instances", Count: len(toPause)})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
 	}
 
-	for _, vm := range toPause {
+	batchErr := common.RunBatch(opts, w, result, toPause, func(vm sdkView.VmInstanceInventoryView, out io.Writer) (*sdkView.VmInstanceInventoryView, error) {
 		resp, err := cli.PauseVmInstance(vm.UUID)
 		if err != nil {
-			failures = append(failures, struct {
-				VM  sdkView.VmInstanceInventoryView
-				Err error
-			}{VM: vm, Err: err})
-			fmt.Printf("Failed to pause %s (%s): %v\n", vm.Name, vm.UUID, err)
-			continue
-		}
-		successes = append(successes, *resp)
-		fmt.Printf("Paused %s (%s)\n", resp.Name, resp.UUID)
-	}
-
-	fmt.Printf("\nSummary: %d paused, %d failed, %d skipped\n", len(successes), len(failures), len(skipped))
-	if len(failures) > 0 {
-		fmt.Println("Failures:")
-		for _, f := range failures {
-			fmt.Printf("  - %s (%s): %v\n", f.VM.Name, f.VM.UUID, f.Err)
+			return nil, err
 		}
-	}
+		fmt.Fprintf(out, "Paused %s (%s)\n", resp.Name, resp.UUID)
+		return resp, nil
+	})
 
-	outputFormat, _ := cmd.Flags().GetString("output")
-	format := utils.ParseFormat(outputFormat)
-	fields, _ := cmd.Flags().GetStringSlice("fields")
-
-	if len(successes) > 0 {
-		if err := utils.PrintVMs(successes, format, fields); err != nil {
-			fmt.Printf("Error formatting output: %s\n", err)
-		}
+	if err := utils.PrintSummary(result, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
 	}
+	return batchErr
 }
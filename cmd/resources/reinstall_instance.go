@@ -0,0 +1,163 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+var ReinstallInstanceCmd = &cobra.Command{
+	Use:   "reinstall <name-or-uuid>",
+	Short: "Rebuild the root volume of a virtual machine instance from an image.",
+	Long: `Rebuild the root volume of a VM instance from the image it was created
+from, or from another image with --image. Everything on the root volume is
+lost; data volumes, NICs, IP addresses and the VM UUID are kept.
+
+A running VM is stopped first and started again afterwards unless
+--no-start is given. You will be prompted for confirmation unless -y/--yes
+is provided.
+
+Examples:
+  zstack-cli instance reinstall ci-runner-01
+  zstack-cli instance reinstall ci-runner-01 --image ubuntu-24.04 -y`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReinstallInstance(cmd, args[0])
+	},
+}
+
+func init() {
+	InstanceCmd.AddCommand(ReinstallInstanceCmd)
+	ReinstallInstanceCmd.Flags().String("image", "", "Image name or UUID to reinstall from (defaults to the VM's current image)")
+	ReinstallInstanceCmd.Flags().Bool("no-start", false, "Leave the VM stopped after reinstalling")
+}
+
+func runReinstallInstance(cmd *cobra.Command, identifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	image, _ := cmd.Flags().GetString("image")
+	noStart, _ := cmd.Flags().GetBool("no-start")
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, identifier)
+	if err != nil {
+		return err
+	}
+	if vm.State != types.VMStateRunning && vm.State != types.VMStateStopped {
+		return fmt.Errorf("%s (%s) is %s; only Running or Stopped VMs can be reinstalled", vm.Name, vm.UUID, vm.State)
+	}
+
+	imageUUID := vm.ImageUUID
+	if image != "" {
+		imageUUID, err = client.GetImageUUIDByName(cli, image)
+		if err != nil {
+			return err
+		}
+	}
+	if imageUUID == "" {
+		return fmt.Errorf("%s (%s) has no source image; specify --image", vm.Name, vm.UUID)
+	}
+
+	img, err := cli.GetImage(imageUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get image %s: %v", imageUUID, err)
+	}
+	if img.MediaType != string(param.RootVolumeTemplate) {
+		return fmt.Errorf("image %s (%s) has media type %s; reinstalling needs a %s image", img.Name, img.UUID, img.MediaType, param.RootVolumeTemplate)
+	}
+	if !types.IsImageReady(img.Status) {
+		return fmt.Errorf("image %s (%s) is %s", img.Name, img.UUID, img.Status)
+	}
+	changeImage := imageUUID != vm.ImageUUID
+
+	wasRunning := vm.State == types.VMStateRunning
+	start := !noStart
+
+	fmt.Fprintf(w, "Will reinstall %s (%s) from image %s (%s)\n", vm.Name, vm.UUID, img.Name, img.UUID)
+	fmt.Fprintf(w, "All data on root volume %s will be lost.\n", vm.RootVolumeUUID)
+	if wasRunning {
+		fmt.Fprintln(w, "The VM is Running and will be stopped first.")
+	}
+	if start {
+		fmt.Fprintln(w, "The VM will be started afterwards.")
+	}
+
+	stopParam := param.StopVmInstanceParam{
+		StopVmInstance: param.StopVmInstanceDetailParam{Type: "grace"},
+	}
+	var calls []utils.APICall
+	if wasRunning {
+		calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, stopParam))
+	}
+	if changeImage {
+		calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, client.ChangeVmImageParams(imageUUID)))
+	} else {
+		calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, utils.EmptyAction("reimageVmInstance")))
+	}
+	if start {
+		calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, utils.EmptyAction("startVmInstance")))
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	if wasRunning {
+		if _, err := cli.StopVmInstance(vm.UUID, stopParam); err != nil {
+			return fmt.Errorf("failed to stop VM: %v", err)
+		}
+		fmt.Fprintf(w, "Stopped %s (%s)\n", vm.Name, vm.UUID)
+	}
+
+	var resp *sdkView.VmInstanceInventoryView
+	if changeImage {
+		resp, err = client.ChangeVmImage(cli, vm.UUID, imageUUID)
+	} else {
+		resp, err = client.ReimageVmInstance(cli, vm.UUID)
+	}
+	if err != nil {
+		if wasRunning {
+			return fmt.Errorf("failed to reinstall VM: %v; the VM was left stopped, run 'zstack-cli instance start %s' to start it", err, vm.UUID)
+		}
+		return fmt.Errorf("failed to reinstall VM: %v", err)
+	}
+	fmt.Fprintf(w, "Reinstalled %s (%s) from image %s\n", resp.Name, resp.UUID, img.Name)
+
+	if start {
+		resp, err = cli.StartVmInstance(vm.UUID, nil)
+		if err != nil {
+			return fmt.Errorf("failed to start VM: %v", err)
+		}
+		fmt.Fprintf(w, "Started %s (%s)\n", resp.Name, resp.UUID)
+	}
+
+	if err := utils.PrintVMs([]sdkView.VmInstanceInventoryView{*resp}, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return nil
+}
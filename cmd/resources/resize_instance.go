@@ -0,0 +1,189 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+var ResizeInstanceCmd = &cobra.Command{
+	Use:   "resize <name-or-uuid>",
+	Short: "Change the CPU and memory of a virtual machine instance (name or uuid).",
+	Long: `Change the CPU count and memory size of a VM instance, either directly
+with --cpu/--memory or by moving it to another --instance-offering.
+
+Running VMs can only grow online when CPU/memory hot plug (the vm.numa
+global config) is enabled; any other change on a running VM takes effect
+after a reboot, which --restart performs right away. Changes to stopped
+VMs take effect at the next start.
+
+Examples:
+  zstack-cli instance resize my-vm --cpu 8 --memory 16G
+  zstack-cli instance resize my-vm --instance-offering big --restart`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runResizeInstance(cmd, args[0])
+	},
+}
+
+func init() {
+	InstanceCmd.AddCommand(ResizeInstanceCmd)
+	ResizeInstanceCmd.Flags().Int("cpu", 0, "New number of CPUs")
+	ResizeInstanceCmd.Flags().String("memory", "", "New memory size (e.g. 16G, 4096M)")
+	ResizeInstanceCmd.Flags().String("instance-offering", "", "Instance offering name or UUID to move the VM to")
+	ResizeInstanceCmd.Flags().Bool("restart", false, "Reboot the VM when the change needs a reboot to take effect")
+}
+
+func runResizeInstance(cmd *cobra.Command, identifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	cpuNum, _ := cmd.Flags().GetInt("cpu")
+	memory, _ := cmd.Flags().GetString("memory")
+	offering, _ := cmd.Flags().GetString("instance-offering")
+	restart, _ := cmd.Flags().GetBool("restart")
+
+	if offering != "" && (cpuNum != 0 || memory != "") {
+		return fmt.Errorf("--instance-offering cannot be combined with --cpu or --memory")
+	}
+	if offering == "" && cpuNum == 0 && memory == "" {
+		return fmt.Errorf("specify --cpu, --memory or --instance-offering")
+	}
+	if cpuNum < 0 {
+		return fmt.Errorf("--cpu must be positive")
+	}
+
+	var memorySize int64
+	if memory != "" {
+		var err error
+		memorySize, err = utils.ParseMemorySize(memory)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, identifier)
+	if err != nil {
+		return err
+	}
+
+	offeringUUID := ""
+	newCPU, newMemory := vm.CPUNum, vm.MemorySize
+	if offering != "" {
+		offeringUUID, err = client.GetInstanceOfferingUUIDByName(cli, offering)
+		if err != nil {
+			return err
+		}
+		inv, err := cli.GetInstanceOffering(offeringUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get instance offering: %v", err)
+		}
+		newCPU, newMemory = inv.CpuNum, inv.MemorySize
+	} else {
+		if cpuNum != 0 {
+			newCPU = cpuNum
+		}
+		if memorySize != 0 {
+			newMemory = memorySize
+		}
+	}
+
+	if newCPU == vm.CPUNum && newMemory == vm.MemorySize && offeringUUID == "" {
+		fmt.Fprintf(w, "%s (%s) already has %d CPU(s) and %s memory.\n", vm.Name, vm.UUID, vm.CPUNum, utils.FormatMemorySize(vm.MemorySize))
+		return nil
+	}
+
+	needsReboot := false
+	if vm.State == types.VMStateRunning {
+		hotPlug, err := client.IsVmHotPlugEnabled(cli)
+		if err != nil {
+			return fmt.Errorf("failed to check CPU/memory hot plug: %v", err)
+		}
+		shrinks := newCPU < vm.CPUNum || newMemory < vm.MemorySize
+		needsReboot = !hotPlug || shrinks
+	}
+
+	fmt.Fprintf(w, "Will resize %s (%s): CPU %d -> %d, memory %s -> %s\n", vm.Name, vm.UUID,
+		vm.CPUNum, newCPU, utils.FormatMemorySize(vm.MemorySize), utils.FormatMemorySize(newMemory))
+	switch {
+	case vm.State != types.VMStateRunning:
+		fmt.Fprintf(w, "The VM is %s; the change takes effect at its next start.\n", vm.State)
+	case needsReboot && restart:
+		fmt.Fprintln(w, "The change needs a reboot; the VM will be restarted.")
+	case needsReboot:
+		fmt.Fprintln(w, "The change needs a reboot to take effect; pass --restart to reboot now.")
+	default:
+		fmt.Fprintln(w, "The change is applied online without a reboot.")
+	}
+
+	var cpuParam *int
+	var memoryParam *int64
+	if newCPU != vm.CPUNum {
+		cpuParam = &newCPU
+	}
+	if newMemory != vm.MemorySize {
+		memoryParam = &newMemory
+	}
+
+	var calls []utils.APICall
+	if offeringUUID != "" {
+		calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, client.ChangeInstanceOfferingParams(offeringUUID)))
+	} else {
+		calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, client.UpdateVmCpuMemoryParams(cpuParam, memoryParam)))
+	}
+	if needsReboot && restart {
+		calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, utils.EmptyAction("rebootVmInstance")))
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	var resp *sdkView.VmInstanceInventoryView
+	if offeringUUID != "" {
+		resp, err = client.ChangeInstanceOffering(cli, vm.UUID, offeringUUID)
+	} else {
+		resp, err = client.UpdateVmCpuMemory(cli, vm.UUID, cpuParam, memoryParam)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resize VM: %v", err)
+	}
+	fmt.Fprintf(w, "Resized %s (%s)\n", resp.Name, resp.UUID)
+
+	if needsReboot && restart {
+		resp, err = cli.RebootVmInstance(vm.UUID)
+		if err != nil {
+			return fmt.Errorf("failed to restart VM: %v", err)
+		}
+		fmt.Fprintf(w, "Restarted %s (%s)\n", resp.Name, resp.UUID)
+	}
+
+	if err := utils.PrintVMs([]sdkView.VmInstanceInventoryView{*resp}, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return nil
+}
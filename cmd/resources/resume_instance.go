@@ -1,139 +1,133 @@
 package resources
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
+	"io"
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
 	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
 	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
 )
 
 var ResumeInstanceCmd = &cobra.Command{
-	Use:   "resume <name-or-uuid>",
-	Short: "Resume a paused virtual machine instance (name or uuid).",
-	Long: `Resume a VM instance that is in 'Paused' state by name or UUID. 
-Supports batch resume if multiple matches are found. You will be prompted
+	Use:   "resume [name-or-uuid...]",
+	Short: "Resume paused virtual machine instances (name or uuid).",
+	Long: `Resume VM instances in 'Paused' state by name or UUID, or every VM
+chosen by --selector, -q, --host, --cluster or --from-file. Names are
+matched exactly unless --match substring is given. You will be prompted
 for confirmation unless -y/--yes is provided.
 
 Example:
   zstack-cli instance resume my-paused-vm`,
-	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		runResumeInstance(cmd, args[0])
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runResumeInstance(cmd, args)
 	},
 }
 
 func init() {
 	InstanceCmd.AddCommand(ResumeInstanceCmd)
+	common.AddBatchFlags(ResumeInstanceCmd)
+	common.AddVMSelectorFlags(ResumeInstanceCmd)
 }
 
-func runResumeInstance(cmd *cobra.Command, identifier string) {
+func runResumeInstance(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	opts, err := common.BatchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	cli := client.GetClient()
 	if cli == nil {
-		fmt.Println("Error: Not logged in. Please run 'zstack-cli login' first.")
-		return
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
 	}
 
-	vms, err := client.GetReadyVMsByNameOrUUID(cli, identifier)
+	vms, err := common.SelectVMs(cmd, cli, args, types.IsVMActive)
 	if err != nil {
-		fmt.Printf("Error querying VMs: %v\n", err)
-		return
+		return err
 	}
 	if len(vms) == 0 {
-		fmt.Printf("No VMs found with name or UUID '%s'.\n", identifier)
-		return
+		fmt.Println("No matching VMs found.")
+		return nil
 	}
 
+	result := utils.NewBatchResult("resume", "resumed")
 	var toResume, skipped []sdkView.VmInstanceInventoryView
 	for _, vm := range vms {
 		if vm.State == "Paused" {
 			toResume = append(toResume, vm)
 		} else {
 			skipped = append(skipped, vm)
+			result.Skip(vm, "not Paused")
 		}
 	}
 
 	if len(toResume) == 0 {
-		fmt.Println("No matched VMs are in 'Paused' state to resume.")
+		fmt.Fprintln(w, "No matched VMs are in 'Paused' state to resume.")
 		if len(skipped) > 0 {
-			fmt.Println("Matched but skipped (not Paused):")
+			fmt.Fprintln(w, "Matched but skipped (not Paused):")
 			for _, s := range skipped {
-				fmt.Printf("  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+				fmt.Fprintf(w, "  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+			}
+		}
+		if utils.IsStructuredFormat(format) {
+			if err := utils.PrintSummary(result, format, fields); err != nil {
+				return fmt.Errorf("failed to format output: %s", err)
 			}
 		}
-		return
+		return nil
 	}
 
-	fmt.Printf("Matched %d VM(s); %d will be resumed, %d will be skipped.\n", len(vms), len(toResume), len(skipped))
-	fmt.Println("Will resume:")
+	fmt.Fprintf(w, "Matched %d VM(s); %d will be resumed, %d will be skipped.\n", len(vms), len(toResume), len(skipped))
+	fmt.Fprintln(w, "Will resume:")
 	for _, s := range toResume {
-		fmt.Printf("  - %s (%s)\n", s.Name, s.UUID)
+		fmt.Fprintf(w, "  - %s (%s)\n", s.Name, s.UUID)
 	}
 	if len(skipped) > 0 {
-		fmt.Println("Skipped (not Paused):")
+		fmt.Fprintln(w, "Skipped (not Paused):")
 		for _, s := range skipped {
-			fmt.Printf("  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+			fmt.Fprintf(w, "  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
 		}
 	}
 
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	if dryRun {
-		fmt.Println("Dry-run: no API calls will be made.")
-		return
-	}
-
-	autoYes, _ := cmd.Flags().GetBool("yes")
-	if !autoYes {
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("Do you want to continue? [y/N]: ")
-		line, _ := reader.ReadString('\n')
-		line = strings.TrimSpace(strings.ToLower(line))
-		if line != "y" && line != "yes" {
-			fmt.Println("Aborted by user.")
-			return
+		calls := make([]utils.APICall, 0, len(toResume))
+		for _, vm := range toResume {
+			calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, utils.EmptyAction("resumeVmInstance")))
 		}
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFormat)
+		return nil
 	}
 
-	var successes []sdkView.VmInstanceInventoryView
-	var failures []struct {
-		VM  sdkView.VmInstanceInventoryView
-		Err error
+	ok, err := common.Confirm(cmd, w, common.Confirmation{Action: "resume", Kind: "VM instances", Count: len(toResume)})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
 	}
 
-	for _, vm := range toResume {
+	batchErr := common.RunBatch(opts, w, result, toResume, func(vm sdkView.VmInstanceInventoryView, out io.Writer) (*sdkView.VmInstanceInventoryView, error) {
 		resp, err := cli.ResumeVmInstance(vm.UUID)
 		if err != nil {
-			failures = append(failures, struct {
-				VM  sdkView.VmInstanceInventoryView
-				Err error
-			}{VM: vm, Err: err})
-			fmt.Printf("Failed to resume %s (%s): %v\n", vm.Name, vm.UUID, err)
-			continue
-		}
-		successes = append(successes, *resp)
-		fmt.Printf("Resumed %s (%s)\n", resp.Name, resp.UUID)
-	}
-
-	fmt.Printf("\nSummary: %d resumed, %d failed, %d skipped\n", len(successes), len(failures), len(skipped))
-	if len(failures) > 0 {
-		fmt.Println("Failures:")
-		for _, f := range failures {
-			fmt.Printf("  - %s (%s): %v\n", f.VM.Name, f.VM.UUID, f.Err)
+			return nil, err
 		}
-	}
+		fmt.Fprintf(out, "Resumed %s (%s)\n", resp.Name, resp.UUID)
+		return resp, nil
+	})
 
-	// 输出格式
-	outputFormat, _ := cmd.Flags().GetString("output")
-	format := utils.ParseFormat(outputFormat)
-	fields, _ := cmd.Flags().GetStringSlice("fields")
-
-	if len(successes) > 0 {
-		if err := utils.PrintVMs(successes, format, fields); err != nil {
-			fmt.Printf("Error formatting output: %s\n", err)
-		}
+	if err := utils.PrintSummary(result, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
 	}
+	return batchErr
 }
@@ -1,12 +1,11 @@
 package resources
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
+	"io"
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
 	"github.com/chijiajian/zstack-cli-go/pkg/types"
 	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
@@ -14,18 +13,23 @@ import (
 )
 
 var StartInstanceCmd = &cobra.Command{
-	Use:   "start <name-or-uuid>",
-	Short: "Start virtual machine instance",
-	Long: `Start a virtual machine instance by specifying its name or UUID.
-If the identifier matches multiple VMs, all in 'Stopped' state will be started (with confirmation).`,
-	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		runStartInstance(cmd, args[0])
+	Use:   "start [name-or-uuid...]",
+	Short: "Start virtual machine instances",
+	Long: `Start virtual machine instances by specifying their names or UUIDs, or
+every VM chosen by --selector, -q, --host, --cluster or --from-file. All
+matched VMs in 'Stopped' state will be started (with confirmation). Names
+are matched exactly unless --match substring is given.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStartInstance(cmd, args)
 	},
 }
 
 func init() {
 	InstanceCmd.AddCommand(StartInstanceCmd)
+	common.AddBatchFlags(StartInstanceCmd)
+	common.AddVMSelectorFlags(StartInstanceCmd)
+	common.AddWaitFlags(StartInstanceCmd)
 
 	/*
 		StartInstanceCmd.Flags().Bool("dry-run", false, "Preview the API request without sending it")
@@ -35,23 +39,32 @@ func init() {
 	*/
 }
 
-func runStartInstance(cmd *cobra.Command, identifier string) {
+func runStartInstance(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	opts, err := common.BatchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	cli := client.GetClient()
 	if cli == nil {
-		fmt.Println("Error: Not logged in. Please run 'zstack-cli login' first.")
-		return
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
 	}
 
-	vms, err := client.GetReadyVMsByNameOrUUID(cli, identifier)
+	vms, err := common.SelectVMs(cmd, cli, args, types.IsVMActive)
 	if err != nil {
-		fmt.Printf("Error querying VMs: %v\n", err)
-		return
+		return err
 	}
 	if len(vms) == 0 {
-		fmt.Printf("No VMs found with name or UUID '%s'.\n", identifier)
-		return
+		fmt.Println("No matching VMs found.")
+		return nil
 	}
 
+	result := utils.NewBatchResult("start", "started")
 	var toStart, skipped []sdkView.VmInstanceInventoryView
 
 	for _, vm := range vms {
@@ -59,87 +72,77 @@ func runStartInstance(cmd *cobra.Command, identifier string) {
 			toStart = append(toStart, vm)
 		} else {
 			skipped = append(skipped, vm)
+			result.Skip(vm, "not Stopped")
 		}
 	}
 
 	if len(toStart) == 0 {
-		fmt.Println("No matched VMs are in 'Stopped' state to start.")
+		fmt.Fprintln(w, "No matched VMs are in 'Stopped' state to start.")
 		if len(skipped) > 0 {
-			fmt.Println("Matched but skipped (not Stopped):")
+			fmt.Fprintln(w, "Matched but skipped (not Stopped):")
 			for _, s := range skipped {
-				fmt.Printf("  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+				fmt.Fprintf(w, "  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
 			}
 		}
-		return
+		if utils.IsStructuredFormat(format) {
+			if err := utils.PrintSummary(result, format, fields); err != nil {
+				return fmt.Errorf("failed to format output: %s", err)
+			}
+		}
+		return nil
 	}
 
-	fmt.Printf("Matched %d VM(s); %d will be started, %d will be skipped.\n", len(vms), len(toStart), len(skipped))
-	fmt.Println("Will start:")
+	fmt.Fprintf(w, "Matched %d VM(s); %d will be started, %d will be skipped.\n", len(vms), len(toStart), len(skipped))
+	fmt.Fprintln(w, "Will start:")
 	for _, s := range toStart {
-		fmt.Printf("  - %s (%s)\n", s.Name, s.UUID)
+		fmt.Fprintf(w, "  - %s (%s)\n", s.Name, s.UUID)
 	}
 	if len(skipped) > 0 {
-		fmt.Println("Skipped (not Stopped):")
+		fmt.Fprintln(w, "Skipped (not Stopped):")
 		for _, s := range skipped {
-			fmt.Printf("  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+			fmt.Fprintf(w, "  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
 		}
 	}
 
 	// dry-run
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	if dryRun {
-		fmt.Println("Dry-run: no API calls will be made.")
-		return
-	}
-
-	autoYes, _ := cmd.Flags().GetBool("yes")
-	if !autoYes {
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("Do you want to continue? [y/N]: ")
-		line, _ := reader.ReadString('\n')
-		line = strings.TrimSpace(strings.ToLower(line))
-		if line != "y" && line != "yes" {
-			fmt.Println("Aborted by user.")
-			return
+		calls := make([]utils.APICall, 0, len(toStart))
+		for _, vm := range toStart {
+			calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, utils.EmptyAction("startVmInstance")))
 		}
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFormat)
+		return nil
 	}
 
-	var successes []sdkView.VmInstanceInventoryView
-	var failures []struct {
-		VM  sdkView.VmInstanceInventoryView
-		Err error
+	ok, err := common.Confirm(cmd, w, common.Confirmation{Action: "start", Kind: "VM instances", Count: len(toStart)})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
 	}
 
-	for _, s := range toStart {
-		resp, err := cli.StartVmInstance(s.UUID, nil)
+	waiter := common.NewWaiter(cmd, w)
+	batchErr := common.RunBatch(opts, w, result, toStart, func(vm sdkView.VmInstanceInventoryView, out io.Writer) (*sdkView.VmInstanceInventoryView, error) {
+		resp, err := cli.StartVmInstance(vm.UUID, nil)
 		if err != nil {
-			failures = append(failures, struct {
-				VM  sdkView.VmInstanceInventoryView
-				Err error
-			}{VM: s, Err: err})
-			fmt.Printf("Failed to start %s (%s): %v\n", s.Name, s.UUID, err)
-			continue
+			return nil, err
 		}
-		successes = append(successes, *resp)
-		fmt.Printf("Started %s (%s)\n", resp.Name, resp.UUID)
-	}
-
-	fmt.Printf("\nSummary: %d started, %d failed, %d skipped\n", len(successes), len(failures), len(skipped))
-	if len(failures) > 0 {
-		fmt.Println("Failures:")
-		for _, f := range failures {
-			fmt.Printf("  - %s (%s): %v\n", f.VM.Name, f.VM.UUID, f.Err)
+		resp, err = waitForVM(waiter.WithOutput(out), cli, *resp, types.VMStateRunning)
+		if err != nil {
+			return nil, err
 		}
-	}
-
-	outputFormat, _ := cmd.Flags().GetString("output")
-	format := utils.ParseFormat(outputFormat)
-
-	fields, _ := cmd.Flags().GetStringSlice("fields")
+		fmt.Fprintf(out, "Started %s (%s)\n", resp.Name, resp.UUID)
+		return resp, nil
+	})
 
-	if len(successes) > 0 {
-		if err := utils.PrintVMs(successes, format, fields); err != nil {
-			fmt.Printf("Error formatting output : %s\n", err)
-		}
+	if err := utils.PrintSummary(result, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	if err := waiter.Finish(); err != nil {
+		return err
 	}
+	return batchErr
 }
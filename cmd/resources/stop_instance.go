@@ -1,12 +1,11 @@
 package resources
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
+	"io"
 
 	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
 	"github.com/chijiajian/zstack-cli-go/pkg/types"
 	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
@@ -15,100 +14,109 @@ import (
 )
 
 var StopInstanceCmd = &cobra.Command{
-	Use:   "stop <name-or-uuid>",
-	Short: "Stop a virtual machine instance (name or uuid).",
-	Long: `Stop a VM instance by name or UUID. 
-Supports batch stop if multiple matches are found. You will be prompted
-for confirmation unless -y/--yes is provided.
-
-Example:
-  zstack-cli instance stop my-vm`,
-	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		runStopInstance(cmd, args[0])
+	Use:   "stop [name-or-uuid...]",
+	Short: "Stop virtual machine instances (name or uuid).",
+	Long: `Stop VM instances by name or UUID, or every VM chosen by --selector,
+-q, --host, --cluster or --from-file. Names are matched exactly unless
+--match substring is given. You will be prompted for confirmation unless
+-y/--yes is provided. VMs with the user tag "protected" are refused unless
+--force is given.
+
+Examples:
+  zstack-cli instance stop my-vm
+  zstack-cli instance stop web-1 web-2
+  zstack-cli instance stop web- --match substring
+  zstack-cli instance stop --selector env=staging --cluster cluster-01`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStopInstance(cmd, args)
 	},
 }
 
 func init() {
 	InstanceCmd.AddCommand(StopInstanceCmd)
+	common.AddBatchFlags(StopInstanceCmd)
+	common.AddVMSelectorFlags(StopInstanceCmd)
+	common.AddWaitFlags(StopInstanceCmd)
+	common.AddForceFlag(StopInstanceCmd)
 	StopInstanceCmd.Flags().Bool("stop-ha", true, "Completely shut down HA VM if applicable")
 	//StopInstanceCmd.Flags().String("stop-type", "grace", "grace stop or not")
 }
 
-func runStopInstance(cmd *cobra.Command, identifier string) {
+func runStopInstance(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	opts, err := common.BatchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	cli := client.GetClient()
 	if cli == nil {
-		fmt.Println("Error: Not logged in. Please run 'zstack-cli login' first.")
-		return
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
 	}
 
-	vms, err := client.GetReadyVMsByNameOrUUID(cli, identifier)
+	vms, err := common.SelectVMs(cmd, cli, args, types.IsVMActive)
 	if err != nil {
-		fmt.Printf("Error querying VMs: %v\n", err)
-		return
+		return err
 	}
 	if len(vms) == 0 {
-		fmt.Printf("No VMs found with name or UUID '%s'.\n", identifier)
-		return
+		fmt.Println("No matching VMs found.")
+		return nil
 	}
 
+	result := utils.NewBatchResult("stop", "stopped")
 	var toStop, skipped []sdkView.VmInstanceInventoryView
 	for _, vm := range vms {
 		if vm.State == types.VMStateRunning {
 			toStop = append(toStop, vm)
 		} else {
 			skipped = append(skipped, vm)
+			result.Skip(vm, "not Running")
 		}
 	}
 
 	if len(toStop) == 0 {
-		fmt.Println("No matched VMs are in 'Running' state to stop.")
+		fmt.Fprintln(w, "No matched VMs are in 'Running' state to stop.")
 		if len(skipped) > 0 {
-			fmt.Println("Matched but skipped (not Running):")
+			fmt.Fprintln(w, "Matched but skipped (not Running):")
 			for _, s := range skipped {
-				fmt.Printf("  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+				fmt.Fprintf(w, "  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+			}
+		}
+		if utils.IsStructuredFormat(format) {
+			if err := utils.PrintSummary(result, format, fields); err != nil {
+				return fmt.Errorf("failed to format output: %s", err)
 			}
 		}
-		return
+		return nil
 	}
 
-	fmt.Printf("Matched %d VM(s); %d will be stopped, %d will be skipped.\n", len(vms), len(toStop), len(skipped))
-	fmt.Println("Will stop:")
+	fmt.Fprintf(w, "Matched %d VM(s); %d will be stopped, %d will be skipped.\n", len(vms), len(toStop), len(skipped))
+	fmt.Fprintln(w, "Will stop:")
 	for _, s := range toStop {
-		fmt.Printf("  - %s (%s)\n", s.Name, s.UUID)
+		fmt.Fprintf(w, "  - %s (%s)\n", s.Name, s.UUID)
 	}
 	if len(skipped) > 0 {
-		fmt.Println("Skipped (not Running):")
+		fmt.Fprintln(w, "Skipped (not Running):")
 		for _, s := range skipped {
-			fmt.Printf("  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+			fmt.Fprintf(w, "  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
 		}
 	}
 
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
-	if dryRun {
-		fmt.Println("Dry-run: no API calls will be made.")
-		return
-	}
-
-	autoYes, _ := cmd.Flags().GetBool("yes")
-	if !autoYes {
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("Do you want to continue? [y/N]: ")
-		line, _ := reader.ReadString('\n')
-		line = strings.TrimSpace(strings.ToLower(line))
-		if line != "y" && line != "yes" {
-			fmt.Println("Aborted by user.")
-			return
-		}
+	uuids := make([]string, 0, len(toStop))
+	for _, vm := range toStop {
+		uuids = append(uuids, vm.UUID)
+	}
+	if err := common.CheckProtected(cmd, w, cli, "v1/vm-instances", uuids, "stop"); err != nil {
+		return err
 	}
 
 	stopHA, _ := cmd.Flags().GetBool("stop-ha")
 	//stopType, _ := cmd.Flags().GetString("stop-type")
-	var successes []sdkView.VmInstanceInventoryView
-	var failures []struct {
-		VM  sdkView.VmInstanceInventoryView
-		Err error
-	}
 
 	p := param.StopVmInstanceParam{
 		StopVmInstance: param.StopVmInstanceDetailParam{
@@ -117,36 +125,44 @@ func runStopInstance(cmd *cobra.Command, identifier string) {
 		},
 	}
 
-	for _, vm := range toStop {
-
-		resp, err := cli.StopVmInstance(vm.UUID, p)
-		if err != nil {
-			failures = append(failures, struct {
-				VM  sdkView.VmInstanceInventoryView
-				Err error
-			}{VM: vm, Err: err})
-			fmt.Printf("Failed to stop %s (%s): %v\n", vm.Name, vm.UUID, err)
-			continue
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		calls := make([]utils.APICall, 0, len(toStop))
+		for _, vm := range toStop {
+			calls = append(calls, utils.ActionCall("v1/vm-instances", vm.UUID, p))
 		}
-		successes = append(successes, *resp)
-		fmt.Printf("Stopped %s (%s)\n", resp.Name, resp.UUID)
+		fmt.Fprintln(w, "Dry-run: no API calls will be made. Requests that would be sent:")
+		utils.PrintDryRun(calls, outputFormat)
+		return nil
 	}
 
-	fmt.Printf("\nSummary: %d stopped, %d failed, %d skipped\n", len(successes), len(failures), len(skipped))
-	if len(failures) > 0 {
-		fmt.Println("Failures:")
-		for _, f := range failures {
-			fmt.Printf("  - %s (%s): %v\n", f.VM.Name, f.VM.UUID, f.Err)
-		}
+	ok, err := common.Confirm(cmd, w, common.Confirmation{Action: "stop", Kind: "VM instances", Count: len(toStop)})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
 	}
 
-	outputFormat, _ := cmd.Flags().GetString("output")
-	format := utils.ParseFormat(outputFormat)
-	fields, _ := cmd.Flags().GetStringSlice("fields")
-
-	if len(successes) > 0 {
-		if err := utils.PrintVMs(successes, format, fields); err != nil {
-			fmt.Printf("Error formatting output: %s\n", err)
+	waiter := common.NewWaiter(cmd, w)
+	batchErr := common.RunBatch(opts, w, result, toStop, func(vm sdkView.VmInstanceInventoryView, out io.Writer) (*sdkView.VmInstanceInventoryView, error) {
+		resp, err := cli.StopVmInstance(vm.UUID, p)
+		if err != nil {
+			return nil, err
 		}
+		resp, err = waitForVM(waiter.WithOutput(out), cli, *resp, types.VMStateStopped)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(out, "Stopped %s (%s)\n", resp.Name, resp.UUID)
+		return resp, nil
+	})
+
+	if err := utils.PrintSummary(result, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	if err := waiter.Finish(); err != nil {
+		return err
 	}
+	return batchErr
 }
@@ -0,0 +1,298 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/types"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+var AttachVolumeCmd = &cobra.Command{
+	Use:   "attach-volume <vm-name-or-uuid> <volume-name-or-uuid>",
+	Short: "Attach a data volume to a virtual machine instance.",
+	Long: `Attach an existing data volume to a VM instance. The volume must not be
+attached to another VM unless it is shareable.
+
+Examples:
+  zstack-cli instance attach-volume my-vm data-01`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAttachVolume(cmd, args[0], args[1])
+	},
+}
+
+var DetachVolumeCmd = &cobra.Command{
+	Use:   "detach-volume <vm-name-or-uuid> <volume-name-or-uuid>",
+	Short: "Detach a data volume from a virtual machine instance.",
+	Long: `Detach a data volume from a VM instance. The volume is kept and can be
+attached again later.
+
+Examples:
+  zstack-cli instance detach-volume my-vm data-01`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDetachVolume(cmd, args[0], args[1])
+	},
+}
+
+var AddDiskCmd = &cobra.Command{
+	Use:   "add-disk <name-or-uuid>",
+	Short: "Create a data volume and attach it to a virtual machine instance.",
+	Long: `Create a new data volume and attach it to a VM instance.
+
+The size comes from --size or from --disk-offering. Without --name the
+volume is called <vm>-data-<n>.
+
+Examples:
+  zstack-cli instance add-disk my-vm --size 100G
+  zstack-cli instance add-disk my-vm --disk-offering ssd-100g --primary-storage ps-01`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAddDisk(cmd, args[0])
+	},
+}
+
+func init() {
+	InstanceCmd.AddCommand(AttachVolumeCmd)
+	InstanceCmd.AddCommand(DetachVolumeCmd)
+	InstanceCmd.AddCommand(AddDiskCmd)
+
+	AddDiskCmd.Flags().String("name", "", "Name of the new data volume")
+	AddDiskCmd.Flags().String("description", "", "Description of the new data volume")
+	AddDiskCmd.Flags().String("size", "", "Size of the new data volume (e.g. 100G, 512M)")
+	AddDiskCmd.Flags().String("disk-offering", "", "Disk offering name or UUID")
+	AddDiskCmd.Flags().String("primary-storage", "", "Primary storage name or UUID for the new data volume")
+}
+
+func runAttachVolume(cmd *cobra.Command, vmIdentifier, volumeIdentifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, vmIdentifier)
+	if err != nil {
+		return err
+	}
+
+	volume, err := client.GetDataVolumeByNameOrUUID(cli, volumeIdentifier)
+	if err != nil {
+		return err
+	}
+
+	if volume.VMInstanceUUID == vm.UUID {
+		fmt.Fprintf(w, "Volume %s (%s) is already attached to %s (%s).\n", volume.Name, volume.UUID, vm.Name, vm.UUID)
+		return nil
+	}
+	if volume.VMInstanceUUID != "" && !volume.IsShareable {
+		return fmt.Errorf("volume %s (%s) is attached to VM %s; detach it first", volume.Name, volume.UUID, volume.VMInstanceUUID)
+	}
+	if volume.State != types.StateEnabled {
+		return fmt.Errorf("volume %s (%s) is %s", volume.Name, volume.UUID, volume.State)
+	}
+
+	fmt.Fprintf(w, "Will attach volume %s (%s, %s) to %s (%s) state=%s\n", volume.Name, volume.UUID,
+		utils.FormatMemorySize(int64(volume.Size)), vm.Name, vm.UUID, vm.State)
+
+	calls := []utils.APICall{
+		utils.PostCall(fmt.Sprintf("v1/volumes/%s/vm-instances/%s", volume.UUID, vm.UUID), nil),
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	resp, err := cli.AttachDataVolumeToVm(volume.UUID, vm.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to attach volume: %v", err)
+	}
+	fmt.Fprintf(w, "Attached volume %s (%s) to %s (%s)\n", resp.Name, resp.UUID, vm.Name, vm.UUID)
+
+	if err := utils.PrintVolumes([]sdkView.VolumeView{*resp}, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return nil
+}
+
+func runDetachVolume(cmd *cobra.Command, vmIdentifier, volumeIdentifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, vmIdentifier)
+	if err != nil {
+		return err
+	}
+
+	volume, err := client.GetDataVolumeByNameOrUUID(cli, volumeIdentifier)
+	if err != nil {
+		return err
+	}
+
+	// Shareable volumes do not record a single VM, so check the VM's own
+	// volume list instead.
+	if !hasVolume(vm.AllVolumes, volume.UUID) {
+		return fmt.Errorf("volume %s (%s) is not attached to %s (%s)", volume.Name, volume.UUID, vm.Name, vm.UUID)
+	}
+
+	fmt.Fprintf(w, "Will detach volume %s (%s, %s) from %s (%s) state=%s\n", volume.Name, volume.UUID,
+		utils.FormatMemorySize(int64(volume.Size)), vm.Name, vm.UUID, vm.State)
+
+	calls := []utils.APICall{
+		utils.DeletePathCall(fmt.Sprintf("v1/volumes/%s/vm-instances?vmUuid=%s", volume.UUID, vm.UUID)),
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	resp, err := cli.DetachDataVolumeFromVm(volume.UUID, vm.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to detach volume: %v", err)
+	}
+	fmt.Fprintf(w, "Detached volume %s (%s) from %s (%s)\n", resp.Name, resp.UUID, vm.Name, vm.UUID)
+
+	if err := utils.PrintVolumes([]sdkView.VolumeView{*resp}, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return nil
+}
+
+func runAddDisk(cmd *cobra.Command, identifier string) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	w := utils.StatusWriter(format)
+
+	name, _ := cmd.Flags().GetString("name")
+	description, _ := cmd.Flags().GetString("description")
+	size, _ := cmd.Flags().GetString("size")
+	diskOffering, _ := cmd.Flags().GetString("disk-offering")
+	primaryStorage, _ := cmd.Flags().GetString("primary-storage")
+
+	if size == "" && diskOffering == "" {
+		return fmt.Errorf("specify --size or --disk-offering")
+	}
+	if size != "" && diskOffering != "" {
+		return fmt.Errorf("--size and --disk-offering cannot be used together")
+	}
+
+	var diskSize int64
+	if size != "" {
+		var err error
+		diskSize, err = utils.ParseMemorySize(size)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+	}
+
+	vm, err := client.GetVMByNameOrUUID(cli, identifier)
+	if err != nil {
+		return err
+	}
+
+	diskOfferingUUID := ""
+	if diskOffering != "" {
+		diskOfferingUUID, err = client.GetDiskOfferingUUIDByName(cli, diskOffering)
+		if err != nil {
+			return err
+		}
+		inv, err := cli.GetDiskOffering(diskOfferingUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get disk offering: %v", err)
+		}
+		diskSize = int64(inv.DiskSize)
+	}
+
+	primaryStorageUUID := ""
+	if primaryStorage != "" {
+		primaryStorageUUID, err = client.GetPrimaryStorageUUIDByName(cli, primaryStorage)
+		if err != nil {
+			return err
+		}
+	}
+
+	if name == "" {
+		dataVolumes := 0
+		for _, vol := range vm.AllVolumes {
+			if vol.Type == "Data" {
+				dataVolumes++
+			}
+		}
+		name = fmt.Sprintf("%s-data-%d", vm.Name, dataVolumes+1)
+	}
+
+	fmt.Fprintf(w, "Will create data volume %s (%s) and attach it to %s (%s) state=%s\n", name,
+		utils.FormatMemorySize(diskSize), vm.Name, vm.UUID, vm.State)
+
+	if diskOfferingUUID != "" {
+		// The offering decides the size; sending both is rejected.
+		diskSize = 0
+	}
+
+	calls := []utils.APICall{
+		utils.PostCall("v1/volumes/data", client.CreateDataVolumeParams(name, description, diskSize, diskOfferingUUID, primaryStorageUUID)),
+		utils.PostCall(fmt.Sprintf("v1/volumes/<new-volume-uuid>/vm-instances/%s", vm.UUID), nil),
+	}
+	if ok, err := confirmInstanceChange(cmd, w, calls); !ok {
+		return err
+	}
+
+	volume, err := client.CreateDataVolume(cli, name, description, diskSize, diskOfferingUUID, primaryStorageUUID)
+	if err != nil {
+		return fmt.Errorf("failed to create data volume: %v", err)
+	}
+	fmt.Fprintf(w, "Created data volume %s (%s)\n", volume.Name, volume.UUID)
+
+	resp, err := cli.AttachDataVolumeToVm(volume.UUID, vm.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to attach volume: %v; the data volume %s (%s) was created but is not attached, run 'zstack-cli instance attach-volume %s %s' to retry",
+			err, volume.Name, volume.UUID, vm.UUID, volume.UUID)
+	}
+	fmt.Fprintf(w, "Attached volume %s (%s) to %s (%s)\n", resp.Name, resp.UUID, vm.Name, vm.UUID)
+
+	if err := utils.PrintVolumes([]sdkView.VolumeView{*resp}, format, fields); err != nil {
+		return fmt.Errorf("failed to format output: %s", err)
+	}
+	return nil
+}
+
+func hasVolume(volumes []sdkView.VolumeView, volumeUUID string) bool {
+	for _, vol := range volumes {
+		if vol.UUID == volumeUUID {
+			return true
+		}
+	}
+	return false
+}
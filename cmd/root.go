@@ -14,6 +14,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -21,7 +22,9 @@ import (
 	"github.com/chijiajian/zstack-cli-go/cmd/del"
 	"github.com/chijiajian/zstack-cli-go/cmd/expunge"
 	"github.com/chijiajian/zstack-cli-go/cmd/get"
+	"github.com/chijiajian/zstack-cli-go/cmd/recovery"
 	"github.com/chijiajian/zstack-cli-go/cmd/resources"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
 	"github.com/chijiajian/zstack-cli-go/pkg/config"
 	"github.com/chijiajian/zstack-cli-go/pkg/utils"
 	"github.com/spf13/cobra"
@@ -40,6 +43,9 @@ var rootCmd = &cobra.Command{
 	Short: "ZStack CLI - manage your ZStack resources",
 	Long:  `zstack-cli is a command-line interface for managing ZStack resources.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Arguments and flags are valid by now, so a failure of the
+		// command itself is reported without the usage text.
+		cmd.SilenceUsage = true
 		if v, _ := cmd.Flags().GetBool("version"); v {
 			fmt.Printf("zstack-cli version: %s, commit: %s\n", version, commit)
 			os.Exit(0)
@@ -52,6 +58,10 @@ var rootCmd = &cobra.Command{
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
+		var timeout *common.WaitTimeoutError
+		if errors.As(err, &timeout) {
+			os.Exit(common.ExitCodeWaitTimeout)
+		}
 		os.Exit(1)
 	}
 }
@@ -66,20 +76,21 @@ func init() {
 	rootCmd.AddCommand(config.ConfigCmd)
 	rootCmd.AddCommand(del.DeleteCmd)
 	rootCmd.AddCommand(expunge.ExpungeCmd)
+	rootCmd.AddCommand(recovery.RecoverCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(resources.InstanceCmd)
 	//rootCmd.AddCommand(cmdutil.ResourceCommand)
 
-	rootCmd.ValidArgs = []string{"create", "delete", "expunge", "get", "login", "config"}
+	rootCmd.ValidArgs = []string{"create", "delete", "expunge", "get", "login", "config", "wait", "recover"}
 	rootCmd.Args = cobra.OnlyValidArgs
 
 	create.CreateCmd.ValidArgs = []string{"disk-offering", "instance-offering", "image", "instance"}
 	create.CreateCmd.Args = cobra.OnlyValidArgs
 
-	del.DeleteCmd.ValidArgs = []string{"images", "instances"}
+	del.DeleteCmd.ValidArgs = subcommandNames(del.DeleteCmd)
 	del.DeleteCmd.Args = cobra.OnlyValidArgs
 
-	expunge.ExpungeCmd.ValidArgs = []string{"images", "instances"}
+	expunge.ExpungeCmd.ValidArgs = subcommandNames(expunge.ExpungeCmd)
 	expunge.ExpungeCmd.Args = cobra.OnlyValidArgs
 
 	get.GetCmd.ValidArgs = []string{
@@ -102,6 +113,15 @@ func init() {
 
 }
 
+// subcommandNames returns the names of the subcommands of cmd.
+func subcommandNames(cmd *cobra.Command) []string {
+	var names []string
+	for _, c := range cmd.Commands() {
+		names = append(names, c.Name())
+	}
+	return names
+}
+
 var completionCmd = &cobra.Command{
 	Use:   "completion [bash|zsh|fish|powershell]",
 	Short: "Generate completion script",
@@ -0,0 +1,206 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/common"
+	"github.com/spf13/cobra"
+)
+
+// waitForDelete is the --for value that waits until resources are gone.
+const waitForDelete = "delete"
+
+var waitCmd = &cobra.Command{
+	Use:   "wait <type>/<name-or-uuid>...",
+	Short: "Wait until resources meet a condition",
+	Long: `Block until one or more resources meet a condition, for use as a barrier
+in scripts.
+
+Resources are named by exact name, UUID or a unique prefix of a UUID. --for
+takes field=value, where field is any field shown by 'get -o json'
+(nested fields are separated by dots), or "delete" to wait until the
+resources no longer exist. --fail-on field=value stops waiting early when a
+resource reaches a state that can never lead to the condition.
+
+By default, or with --all, every resource must meet the condition; with
+--any one is enough. The exit status is 0 when the condition is met, 1 on errors and
+3 when --timeout expires.
+
+Examples:
+  zstack-cli wait instance/web-1 --for state=Running
+  zstack-cli wait image/c84c3b7 --for status=Ready --timeout 30m
+  zstack-cli wait long-job/<uuid> --for state=Succeeded --fail-on state=Failed
+  zstack-cli wait instance/web-1 instance/web-2 --for state=Running --any`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWait(cmd, args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(waitCmd)
+	waitCmd.Flags().String("for", "", "Condition to wait for: field=value or delete (required)")
+	waitCmd.Flags().StringArray("fail-on", nil, "Stop with an error when a resource has field=value; can be repeated")
+	waitCmd.Flags().Bool("all", false, "Wait until every resource meets the condition (the default)")
+	waitCmd.Flags().Bool("any", false, "Wait until at least one resource meets the condition")
+	waitCmd.Flags().Duration("timeout", common.DefaultWaitTimeout, "Maximum time to wait (e.g. 90s, 10m)")
+	waitCmd.MarkFlagRequired("for")
+}
+
+// waitTarget is one <type>/<name-or-uuid> argument.
+type waitTarget struct {
+	arg  string
+	path string
+	id   string
+}
+
+// fieldCondition is a parsed field=value flag.
+type fieldCondition struct {
+	field string
+	value string
+}
+
+func parseFieldCondition(flag, s string) (fieldCondition, error) {
+	field, value, ok := strings.Cut(s, "=")
+	if !ok || field == "" {
+		return fieldCondition{}, fmt.Errorf("invalid %s '%s', expected field=value", flag, s)
+	}
+	return fieldCondition{field: field, value: value}, nil
+}
+
+func runWait(cmd *cobra.Command, args []string) error {
+	forFlag, _ := cmd.Flags().GetString("for")
+	failOnFlags, _ := cmd.Flags().GetStringArray("fail-on")
+	allFlag, _ := cmd.Flags().GetBool("all")
+	anyFlag, _ := cmd.Flags().GetBool("any")
+	if allFlag && anyFlag {
+		return fmt.Errorf("--all and --any cannot be used together")
+	}
+
+	var cond fieldCondition
+	forDelete := forFlag == waitForDelete
+	if !forDelete {
+		var err error
+		if cond, err = parseFieldCondition("--for", forFlag); err != nil {
+			return err
+		}
+	}
+
+	var failOn []fieldCondition
+	for _, f := range failOnFlags {
+		c, err := parseFieldCondition("--fail-on", f)
+		if err != nil {
+			return err
+		}
+		failOn = append(failOn, c)
+	}
+
+	var targets []waitTarget
+	for _, arg := range args {
+		kind, id, ok := strings.Cut(arg, "/")
+		if !ok || kind == "" || id == "" {
+			return fmt.Errorf("invalid resource '%s', expected <type>/<name-or-uuid>", arg)
+		}
+		rt, err := client.LookupResourceType(kind)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, waitTarget{arg: arg, path: rt.Path, id: id})
+	}
+
+	cli := client.GetClient()
+	if cli == nil {
+		return fmt.Errorf("not logged in. Please run 'zstack-cli login' first")
+	}
+
+	condition := forFlag
+	if forDelete {
+		condition = "deleted"
+	}
+
+	waiter := common.NewWaiter(cmd, os.Stdout)
+	firstPoll := true
+	err := waiter.UntilDone(condition, func() (bool, string, error) {
+		met := 0
+		var states []string
+		for _, t := range targets {
+			inventories, err := client.FindResourcesByUUIDPrefix(cli, t.path, t.id)
+			if err != nil {
+				return false, "", err
+			}
+			if len(inventories) == 0 && firstPoll && !forDelete {
+				return false, "", fmt.Errorf("%s not found", t.arg)
+			}
+
+			ok, state, err := checkWaitTarget(t, inventories, forDelete, cond, failOn)
+			states = append(states, state)
+			if err != nil {
+				return false, strings.Join(states, ", "), err
+			}
+			if ok {
+				met++
+			}
+		}
+		firstPoll = false
+
+		done := met == len(targets)
+		if anyFlag {
+			done = met > 0
+		}
+		return done, strings.Join(states, ", "), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Condition met: %s\n", condition)
+	return nil
+}
+
+// checkWaitTarget reports whether every resource matched by t meets the
+// condition, along with a short description of their state.
+func checkWaitTarget(t waitTarget, inventories []map[string]interface{}, forDelete bool, cond fieldCondition, failOn []fieldCondition) (bool, string, error) {
+	if forDelete {
+		if len(inventories) == 0 {
+			return true, t.arg + " gone", nil
+		}
+		return false, fmt.Sprintf("%s exists", t.arg), nil
+	}
+	if len(inventories) == 0 {
+		return false, t.arg + " not found", nil
+	}
+
+	ok := true
+	var values []string
+	for _, inv := range inventories {
+		for _, f := range failOn {
+			if client.FieldValue(inv, f.field) == f.value {
+				state := fmt.Sprintf("%s %s=%s", t.arg, f.field, f.value)
+				return false, state, fmt.Errorf("%s has %s=%s", t.arg, f.field, f.value)
+			}
+		}
+		v := client.FieldValue(inv, cond.field)
+		values = append(values, v)
+		if v != cond.value {
+			ok = false
+		}
+	}
+	return ok, fmt.Sprintf("%s %s=%s", t.arg, cond.field, strings.Join(values, ",")), nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+// groupPageSize is the number of distinct values fetched per request while
+// grouping.
+const groupPageSize = 1000
+
+// GroupCount is the number of resources sharing one value of a field.
+type GroupCount struct {
+	Value string `json:"value" yaml:"value" header:"VALUE"`
+	Count int    `json:"count" yaml:"count" header:"COUNT"`
+}
+
+// CountResources asks the server how many resources under resource (for
+// example "v1/vm-instances") match queryParam, without fetching any rows.
+func CountResources(cli *sdkClient.ZSClient, resource string, queryParam param.QueryParam) (int, error) {
+	p := aggregateParam(queryParam)
+	p.Count(true)
+
+	var resp struct {
+		Total int `json:"total"`
+	}
+	if err := cli.ListWithRespKey(resource, "", &p, &resp); err != nil {
+		return 0, err
+	}
+	return resp.Total, nil
+}
+
+// CountResourcesBy counts the resources under resource matching queryParam
+// for each distinct value of field. The server groups the rows by field, so
+// only one row per value is fetched, and counts each group. Resources
+// without a value are counted in a group with an empty value. Only top-level
+// scalar fields can be grouped by; the server rejects unknown fields. Groups
+// are sorted by descending count, then by value.
+func CountResourcesBy(cli *sdkClient.ZSClient, resource string, queryParam param.QueryParam, field string) ([]GroupCount, error) {
+	if field == "" || strings.ContainsAny(field, ". ") {
+		return nil, fmt.Errorf("cannot group by '%s': only top-level fields are supported", field)
+	}
+
+	values, err := distinctValues(cli, resource, queryParam, field)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]GroupCount, 0, len(values))
+	for _, v := range values {
+		p := aggregateParam(queryParam)
+		if v.null {
+			// Also validates field: the server rejects a condition on a
+			// field the resource does not have.
+			p.AddQ(fmt.Sprintf("%s is null", field))
+		} else {
+			p.AddQ(fmt.Sprintf("%s=%s", field, v.value))
+		}
+		count, err := CountResources(cli, resource, p)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			groups = append(groups, GroupCount{Value: v.value, Count: count})
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups, nil
+}
+
+type groupValue struct {
+	value string
+	null  bool
+}
+
+// distinctValues returns the values of field among the resources under
+// resource matching queryParam, each once.
+func distinctValues(cli *sdkClient.ZSClient, resource string, queryParam param.QueryParam, field string) ([]groupValue, error) {
+	seen := make(map[groupValue]bool)
+	var values []groupValue
+	for start := 0; ; start += groupPageSize {
+		p := aggregateParam(queryParam)
+		p.GroupBy(field)
+		p.Fields([]string{field})
+		p.Start(start)
+		p.Limit(groupPageSize)
+
+		items, err := QueryResources(cli, resource, p)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			var v groupValue
+			switch item[field].(type) {
+			case nil:
+				v.null = true
+			case map[string]interface{}, []interface{}:
+				return nil, fmt.Errorf("cannot group by '%s': it is not a scalar field", field)
+			default:
+				v.value = FieldValue(item, field)
+			}
+			if !seen[v] {
+				seen[v] = true
+				values = append(values, v)
+			}
+		}
+		if len(items) < groupPageSize {
+			return values, nil
+		}
+	}
+}
+
+// aggregateParam copies the conditions of queryParam, dropping paging,
+// projection and aggregate options that the caller sets itself.
+func aggregateParam(queryParam param.QueryParam) param.QueryParam {
+	p := param.QueryParam{Values: url.Values{}}
+	for k, v := range queryParam.Values {
+		switch k {
+		case "count", "groupBy", "replyWithCount", "total", "fields", "start", "limit", "sort":
+			continue
+		}
+		p.Values[k] = append([]string(nil), v...)
+	}
+	return p
+}
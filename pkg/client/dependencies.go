@@ -0,0 +1,92 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+// Dependency describes resources that refer to a resource of another type
+// and are affected when it is deleted.
+type Dependency struct {
+	// Label names the dependent resources, e.g. "VM instances using it".
+	Label string
+	Path  string
+	// Field of the dependent inventory that holds the UUID of the resource.
+	Field string
+	// Extra query conditions, e.g. to select data volumes only.
+	Conditions []string
+	// Effect says what deleting the resource does to the dependents.
+	Effect string
+}
+
+// Dependencies lists the dependents of each resource type by
+// ResourceType.Name.
+var Dependencies = map[string][]Dependency{
+	"instances": {
+		{Label: "data volumes attached to it", Path: "v1/volumes", Field: "vmInstanceUuid", Conditions: []string{"type=Data"}, Effect: "detached from it"},
+	},
+	"images": {
+		{Label: "VM instances created from it", Path: "v1/vm-instances", Field: "imageUuid", Effect: "kept, but cannot be reinstalled from it"},
+	},
+	"disks": {
+		{Label: "snapshots of it", Path: "v1/volume-snapshots", Field: "volumeUuid", Effect: "deleted with it"},
+	},
+	"instance-offerings": {
+		{Label: "VM instances using it", Path: "v1/vm-instances", Field: "instanceOfferingUuid", Effect: "kept with their current configuration"},
+	},
+	"disk-offerings": {
+		{Label: "volumes created from it", Path: "v1/volumes", Field: "diskOfferingUuid", Effect: "kept"},
+	},
+	"l2-networks": {
+		{Label: "L3 networks on it", Path: "v1/l3-networks", Field: "l2NetworkUuid", Effect: "deleted with it"},
+	},
+	"l3-networks": {
+		{Label: "VM instances using it", Path: "v1/vm-instances", Field: "vmNics.l3NetworkUuid", Effect: "detached from it"},
+		{Label: "VIPs on it", Path: "v1/vips", Field: "l3NetworkUuid", Effect: "deleted with it"},
+		{Label: "IP ranges of it", Path: "v1/l3-networks/ip-ranges", Field: "l3NetworkUuid", Effect: "deleted with it"},
+	},
+	"vips": {
+		{Label: "EIPs using it", Path: "v1/eips", Field: "vipUuid", Effect: "deleted with it"},
+	},
+	"primary-storages": {
+		{Label: "volumes on it", Path: "v1/volumes", Field: "primaryStorageUuid", Effect: "lost with it"},
+	},
+	"image-storages": {
+		{Label: "images on it", Path: "v1/images", Field: "backupStorageRefs.backupStorageUuid", Effect: "kept on their other image storages"},
+	},
+	"zones": {
+		{Label: "clusters in it", Path: "v1/clusters", Field: "zoneUuid", Effect: "deleted with it"},
+	},
+	"clusters": {
+		{Label: "hosts in it", Path: "v1/hosts", Field: "clusterUuid", Effect: "deleted with it"},
+	},
+	"hosts": {
+		{Label: "VM instances on it", Path: "v1/vm-instances", Field: "hostUuid", Effect: "stopped or migrated"},
+	},
+}
+
+// FindDependents returns the resources of dep that refer to uuid.
+func FindDependents(cli *sdkClient.ZSClient, dep Dependency, uuid string) ([]map[string]interface{}, error) {
+	queryParam := param.NewQueryParam()
+	queryParam.AddQ(fmt.Sprintf("%s=%s", dep.Field, uuid))
+	for _, c := range dep.Conditions {
+		queryParam.AddQ(c)
+	}
+	return QueryResources(cli, dep.Path, queryParam)
+}
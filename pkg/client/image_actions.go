@@ -0,0 +1,32 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+// RecoverImage brings a Deleted image back on the backup storages it was
+// deleted from. The SDK always sends backupStorageUuids, and an empty list
+// would recover the image nowhere.
+func RecoverImage(cli *sdkClient.ZSClient, imageUUID string) (*view.ImageView, error) {
+	var resp view.ImageView
+	params := map[string]interface{}{"recoverImage": map[string]string{}}
+	if err := cli.Put("v1/images", imageUUID, params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+// ResourceKind identifies a resource type whose names can be resolved.
+type ResourceKind string
+
+const (
+	KindZone             ResourceKind = "zone"
+	KindCluster          ResourceKind = "cluster"
+	KindHost             ResourceKind = "host"
+	KindImage            ResourceKind = "image"
+	KindInstanceOffering ResourceKind = "instance-offering"
+	KindDiskOffering     ResourceKind = "disk-offering"
+	KindPrimaryStorage   ResourceKind = "primary-storage"
+	KindL2Network        ResourceKind = "l2-network"
+	KindL3Network        ResourceKind = "l3-network"
+	KindVmInstance       ResourceKind = "instance"
+)
+
+// resolveBatchSize bounds the number of UUIDs sent in a single "uuid?=" query
+// so that the request URL stays reasonably short.
+const resolveBatchSize = 100
+
+// NameResolver maps resource UUIDs to names. UUIDs are collected with Add and
+// then fetched with one query per kind (per batch) in Fetch. A nil resolver is
+// valid and resolves nothing, which lets callers skip resolution without
+// branching.
+type NameResolver struct {
+	cli     *sdkClient.ZSClient
+	pending map[ResourceKind]map[string]struct{}
+	names   map[string]string
+}
+
+// NewNameResolver creates an empty resolver.
+func NewNameResolver(cli *sdkClient.ZSClient) *NameResolver {
+	return &NameResolver{
+		cli:     cli,
+		pending: make(map[ResourceKind]map[string]struct{}),
+		names:   make(map[string]string),
+	}
+}
+
+// Add queues uuid for resolution as kind. Empty UUIDs are ignored.
+func (r *NameResolver) Add(kind ResourceKind, uuid string) {
+	if r == nil || uuid == "" {
+		return
+	}
+	if _, ok := r.names[uuid]; ok {
+		return
+	}
+	if r.pending[kind] == nil {
+		r.pending[kind] = make(map[string]struct{})
+	}
+	r.pending[kind][uuid] = struct{}{}
+}
+
+// Fetch resolves every queued UUID.
+func (r *NameResolver) Fetch() error {
+	if r == nil {
+		return nil
+	}
+	for kind, set := range r.pending {
+		uuids := make([]string, 0, len(set))
+		for uuid := range set {
+			uuids = append(uuids, uuid)
+		}
+
+		for start := 0; start < len(uuids); start += resolveBatchSize {
+			end := start + resolveBatchSize
+			if end > len(uuids) {
+				end = len(uuids)
+			}
+			if err := r.fetch(kind, uuids[start:end]); err != nil {
+				return fmt.Errorf("failed to resolve %s names: %v", kind, err)
+			}
+		}
+	}
+	r.pending = make(map[ResourceKind]map[string]struct{})
+	return nil
+}
+
+// Name returns the resolved name of uuid, or "" if it is unknown.
+func (r *NameResolver) Name(uuid string) string {
+	if r == nil {
+		return ""
+	}
+	return r.names[uuid]
+}
+
+func (r *NameResolver) fetch(kind ResourceKind, uuids []string) error {
+	queryParam := param.NewQueryParam()
+	queryParam.AddQ(fmt.Sprintf("uuid?=%s", strings.Join(uuids, ",")))
+	queryParam.Limit(len(uuids))
+	queryParam.Fields([]string{"uuid", "name"})
+
+	switch kind {
+	case KindZone:
+		zones, err := r.cli.QueryZone(queryParam)
+		if err != nil {
+			return err
+		}
+		for _, z := range zones {
+			r.names[z.UUID] = z.Name
+		}
+	case KindCluster:
+		clusters, err := r.cli.QueryCluster(queryParam)
+		if err != nil {
+			return err
+		}
+		for _, c := range clusters {
+			r.names[c.Uuid] = c.Name
+		}
+	case KindHost:
+		hosts, err := r.cli.QueryHost(queryParam)
+		if err != nil {
+			return err
+		}
+		for _, h := range hosts {
+			r.names[h.UUID] = h.Name
+		}
+	case KindImage:
+		images, err := r.cli.QueryImage(queryParam)
+		if err != nil {
+			return err
+		}
+		for _, img := range images {
+			r.names[img.UUID] = img.Name
+		}
+	case KindInstanceOffering:
+		offerings, err := r.cli.QueryInstaceOffering(queryParam)
+		if err != nil {
+			return err
+		}
+		for _, o := range offerings {
+			r.names[o.UUID] = o.Name
+		}
+	case KindDiskOffering:
+		offerings, err := r.cli.QueryDiskOffering(queryParam)
+		if err != nil {
+			return err
+		}
+		for _, o := range offerings {
+			r.names[o.UUID] = o.Name
+		}
+	case KindPrimaryStorage:
+		storages, err := r.cli.QueryPrimaryStorage(queryParam)
+		if err != nil {
+			return err
+		}
+		for _, ps := range storages {
+			r.names[ps.UUID] = ps.Name
+		}
+	case KindL2Network:
+		networks, err := r.cli.QueryL2Network(queryParam)
+		if err != nil {
+			return err
+		}
+		for _, n := range networks {
+			r.names[n.UUID] = n.Name
+		}
+	case KindL3Network:
+		networks, err := r.cli.QueryL3Network(queryParam)
+		if err != nil {
+			return err
+		}
+		for _, n := range networks {
+			r.names[n.UUID] = n.Name
+		}
+	case KindVmInstance:
+		vms, err := r.cli.QueryVmInstance(queryParam)
+		if err != nil {
+			return err
+		}
+		for _, vm := range vms {
+			r.names[vm.UUID] = vm.Name
+		}
+	default:
+		return fmt.Errorf("unsupported resource kind '%s'", kind)
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ package client
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/chijiajian/zstack-cli-go/pkg/types"
 	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
@@ -272,6 +273,44 @@ func GetPrimaryStorageUUIDByName(cli *sdkClient.ZSClient, nameOrUUID string) (st
 	return "", fmt.Errorf("primary storage with name or UUID '%s' not found", nameOrUUID)
 }
 
+// GetDiskOfferingUUIDByName returns the UUID of the disk offering whose
+// UUID or exact name is nameOrUUID. A name shared by several offerings is
+// an error, so that a similar offering is never picked by accident.
+func GetDiskOfferingUUIDByName(cli *sdkClient.ZSClient, nameOrUUID string) (string, error) {
+
+	queryParam := param.NewQueryParam()
+	queryParam.AddQ(fmt.Sprintf("uuid=%s", nameOrUUID))
+
+	diskOfferings, err := cli.QueryDiskOffering(queryParam)
+	if err != nil {
+		return "", err
+	}
+
+	if len(diskOfferings) > 0 {
+		return diskOfferings[0].UUID, nil
+	}
+
+	queryParam = param.NewQueryParam()
+	queryParam.AddQ(fmt.Sprintf("name=%s", nameOrUUID))
+
+	diskOfferings, err = cli.QueryDiskOffering(queryParam)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(diskOfferings) {
+	case 0:
+		return "", fmt.Errorf("disk offering with name or UUID '%s' not found", nameOrUUID)
+	case 1:
+		return diskOfferings[0].UUID, nil
+	}
+	uuids := make([]string, 0, len(diskOfferings))
+	for _, offering := range diskOfferings {
+		uuids = append(uuids, offering.UUID)
+	}
+	return "", fmt.Errorf("%d disk offerings are named '%s', use a UUID instead: %s", len(diskOfferings), nameOrUUID, strings.Join(uuids, ", "))
+}
+
 // GetReadyImagesByNameOrUUID
 func GetReadyImagesByNameOrUUID(cli *sdkClient.ZSClient, nameOrUUID string) ([]view.ImageView, error) {
 	queryParam := param.NewQueryParam()
@@ -387,3 +426,121 @@ func GetDestroyedVMsByNameOrUUID(cli *sdkClient.ZSClient, nameOrUUID string) ([]
 
 	return destroyedVMs, nil
 }
+
+// GetVMByNameOrUUID returns the single active VM matching nameOrUUID. An exact
+// name or UUID match is preferred over partial name matches.
+func GetVMByNameOrUUID(cli *sdkClient.ZSClient, nameOrUUID string) (*view.VmInstanceInventoryView, error) {
+	vms, err := GetReadyVMsByNameOrUUID(cli, nameOrUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vms) == 0 {
+		return nil, fmt.Errorf("VM with name or UUID '%s' not found", nameOrUUID)
+	}
+	if len(vms) == 1 {
+		return &vms[0], nil
+	}
+
+	var exact []view.VmInstanceInventoryView
+	var matches []string
+	for _, vm := range vms {
+		if vm.UUID == nameOrUUID || vm.Name == nameOrUUID {
+			exact = append(exact, vm)
+		}
+		matches = append(matches, fmt.Sprintf("%s (%s)", vm.Name, vm.UUID))
+	}
+	if len(exact) == 1 {
+		return &exact[0], nil
+	}
+
+	return nil, fmt.Errorf("'%s' matches %d VMs: %s; use a UUID to pick one", nameOrUUID, len(vms), strings.Join(matches, ", "))
+}
+
+// GetDataVolumeByNameOrUUID returns the single data volume matching
+// nameOrUUID. An exact name or UUID match is preferred over partial name
+// matches.
+func GetDataVolumeByNameOrUUID(cli *sdkClient.ZSClient, nameOrUUID string) (*view.VolumeView, error) {
+	queryParam := param.NewQueryParam()
+	queryParam.AddQ("type=Data")
+	queryParam.AddQ(fmt.Sprintf("name~=%s", nameOrUUID))
+
+	volumes, err := cli.QueryVolume(queryParam)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(volumes) == 0 {
+		queryParam = param.NewQueryParam()
+		queryParam.AddQ("type=Data")
+		queryParam.AddQ(fmt.Sprintf("uuid=%s", nameOrUUID))
+		volumes, err = cli.QueryVolume(queryParam)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(volumes) == 0 {
+		return nil, fmt.Errorf("data volume with name or UUID '%s' not found", nameOrUUID)
+	}
+	if len(volumes) == 1 {
+		return &volumes[0], nil
+	}
+
+	var exact []view.VolumeView
+	var matches []string
+	for _, vol := range volumes {
+		if vol.UUID == nameOrUUID || vol.Name == nameOrUUID {
+			exact = append(exact, vol)
+		}
+		matches = append(matches, fmt.Sprintf("%s (%s)", vol.Name, vol.UUID))
+	}
+	if len(exact) == 1 {
+		return &exact[0], nil
+	}
+
+	return nil, fmt.Errorf("'%s' matches %d data volumes: %s; use a UUID to pick one", nameOrUUID, len(volumes), strings.Join(matches, ", "))
+}
+
+// GetISOByNameOrUUID returns the single ready ISO image matching nameOrUUID.
+// Images of another media type are reported rather than silently skipped.
+func GetISOByNameOrUUID(cli *sdkClient.ZSClient, nameOrUUID string) (*view.ImageView, error) {
+	images, err := GetReadyImagesByNameOrUUID(cli, nameOrUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var isos []view.ImageView
+	var others []string
+	for _, img := range images {
+		if img.MediaType == string(param.ISO) {
+			isos = append(isos, img)
+		} else {
+			others = append(others, fmt.Sprintf("%s (%s) mediaType=%s", img.Name, img.UUID, img.MediaType))
+		}
+	}
+
+	if len(isos) == 0 {
+		if len(others) > 0 {
+			return nil, fmt.Errorf("'%s' is not an ISO image: %s", nameOrUUID, strings.Join(others, ", "))
+		}
+		return nil, fmt.Errorf("ISO image with name or UUID '%s' not found", nameOrUUID)
+	}
+	if len(isos) == 1 {
+		return &isos[0], nil
+	}
+
+	var exact []view.ImageView
+	var matches []string
+	for _, img := range isos {
+		if img.UUID == nameOrUUID || img.Name == nameOrUUID {
+			exact = append(exact, img)
+		}
+		matches = append(matches, fmt.Sprintf("%s (%s)", img.Name, img.UUID))
+	}
+	if len(exact) == 1 {
+		return &exact[0], nil
+	}
+
+	return nil, fmt.Errorf("'%s' matches %d ISO images: %s; use a UUID to pick one", nameOrUUID, len(isos), strings.Join(matches, ", "))
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+// ResourceType maps the resource names used on the command line to the
+// API path that lists them. The first alias is the singular name.
+type ResourceType struct {
+	Name    string
+	Aliases []string
+	Path    string
+	// DeletePath is where the resource is deleted, or "" if it cannot be
+	// deleted generically.
+	DeletePath string
+}
+
+// ResourceTypes lists the resource types that can be queried generically.
+// Names follow the get subcommands.
+var ResourceTypes = []ResourceType{
+	{Name: "instances", Aliases: []string{"instance", "vm", "vms"}, Path: "v1/vm-instances", DeletePath: "v1/vm-instances"},
+	{Name: "images", Aliases: []string{"image"}, Path: "v1/images", DeletePath: "v1/images"},
+	{Name: "disks", Aliases: []string{"disk", "volume", "volumes"}, Path: "v1/volumes", DeletePath: "v1/volumes"},
+	{Name: "snapshots", Aliases: []string{"snapshot", "snap"}, Path: "v1/volume-snapshots", DeletePath: "v1/volume-snapshots"},
+	{Name: "hosts", Aliases: []string{"host"}, Path: "v1/hosts", DeletePath: "v1/hosts"},
+	{Name: "clusters", Aliases: []string{"cluster"}, Path: "v1/clusters", DeletePath: "v1/clusters"},
+	{Name: "zones", Aliases: []string{"zone"}, Path: "v1/zones", DeletePath: "v1/zones"},
+	{Name: "primary-storages", Aliases: []string{"primary-storage"}, Path: "v1/primary-storage", DeletePath: "v1/primary-storage"},
+	{Name: "image-storages", Aliases: []string{"image-storage", "backup-storage", "backup-storages"}, Path: "v1/backup-storage", DeletePath: "v1/backup-storage"},
+	{Name: "l2-networks", Aliases: []string{"l2-network"}, Path: "v1/l2-networks", DeletePath: "v1/l2-networks"},
+	{Name: "l3-networks", Aliases: []string{"l3-network"}, Path: "v1/l3-networks", DeletePath: "v1/l3-networks"},
+	{Name: "ip-ranges", Aliases: []string{"ip-range"}, Path: "v1/l3-networks/ip-ranges", DeletePath: "v1/l3-networks/ip-ranges"},
+	{Name: "nics", Aliases: []string{"nic", "vm-nics"}, Path: "v1/vm-instances/nics"},
+	{Name: "cdroms", Aliases: []string{"cdrom", "cd-roms"}, Path: "v1/vm-instances/cdroms"},
+	{Name: "vips", Aliases: []string{"vip"}, Path: "v1/vips", DeletePath: "v1/vips"},
+	{Name: "eips", Aliases: []string{"eip"}, Path: "v1/eips", DeletePath: "v1/eips"},
+	{Name: "virtual-routers", Aliases: []string{"virtual-router"}, Path: "v1/vm-instances/appliances/virtual-routers", DeletePath: "v1/vm-instances"},
+	{Name: "instance-offerings", Aliases: []string{"instance-offering"}, Path: "v1/instance-offerings", DeletePath: "v1/instance-offerings"},
+	{Name: "disk-offerings", Aliases: []string{"disk-offering"}, Path: "v1/disk-offerings", DeletePath: "v1/disk-offerings"},
+	{Name: "virtual-router-offerings", Aliases: []string{"virtual-router-offering"}, Path: "v1/instance-offerings/virtual-routers", DeletePath: "v1/instance-offerings"},
+	{Name: "tags", Aliases: []string{"tag"}, Path: "v1/tags", DeletePath: "v1/tags"},
+	{Name: "vm-scripts", Aliases: []string{"vm-script", "scripts", "script"}, Path: "v1/scripts", DeletePath: "v1/scripts"},
+	{Name: "long-jobs", Aliases: []string{"long-job", "jobs", "job"}, Path: "v1/longjobs"},
+}
+
+// DeleteResource deletes the resource uuid of type t.
+func DeleteResource(cli *sdkClient.ZSClient, t *ResourceType, uuid string, mode param.DeleteMode) error {
+	if t.DeletePath == "" {
+		return fmt.Errorf("%s cannot be deleted", t.Name)
+	}
+	return cli.Delete(t.DeletePath, uuid, string(mode))
+}
+
+// LookupResourceType finds a resource type by name or alias.
+func LookupResourceType(name string) (*ResourceType, error) {
+	name = strings.ToLower(name)
+	for i, t := range ResourceTypes {
+		if t.Name == name {
+			return &ResourceTypes[i], nil
+		}
+		for _, a := range t.Aliases {
+			if a == name {
+				return &ResourceTypes[i], nil
+			}
+		}
+	}
+
+	names := make([]string, 0, len(ResourceTypes))
+	for _, t := range ResourceTypes {
+		names = append(names, t.Name)
+	}
+	return nil, fmt.Errorf("unknown resource type '%s', must be one of: %s", name, strings.Join(names, ", "))
+}
+
+// QueryResources returns the raw inventories under path matching
+// queryParam, for callers that read fields by name.
+func QueryResources(cli *sdkClient.ZSClient, path string, queryParam param.QueryParam) ([]map[string]interface{}, error) {
+	var resp []map[string]interface{}
+	if err := cli.List(path, &queryParam, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FindResources returns the inventories under path whose UUID or, failing
+// that, name equals nameOrUUID.
+func FindResources(cli *sdkClient.ZSClient, path, nameOrUUID string) ([]map[string]interface{}, error) {
+	queryParam := param.NewQueryParam()
+	queryParam.AddQ(fmt.Sprintf("uuid=%s", nameOrUUID))
+	found, err := QueryResources(cli, path, queryParam)
+	if err != nil || len(found) > 0 {
+		return found, err
+	}
+
+	queryParam = param.NewQueryParam()
+	queryParam.AddQ(fmt.Sprintf("name=%s", nameOrUUID))
+	return QueryResources(cli, path, queryParam)
+}
+
+// FindResourcesByUUIDPrefix is FindResources that also accepts a unique
+// prefix of a UUID, as printed in shortened output. A prefix matching more
+// than one resource is an error.
+func FindResourcesByUUIDPrefix(cli *sdkClient.ZSClient, path, nameOrUUID string) ([]map[string]interface{}, error) {
+	found, err := FindResources(cli, path, nameOrUUID)
+	if err != nil || len(found) > 0 || !isUUIDPrefix(nameOrUUID) {
+		return found, err
+	}
+
+	queryParam := param.NewQueryParam()
+	queryParam.AddQ(fmt.Sprintf("uuid~=%s%%", nameOrUUID))
+	found, err = QueryResources(cli, path, queryParam)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) > 1 {
+		var uuids []string
+		for _, inv := range found {
+			uuids = append(uuids, FieldValue(inv, "uuid"))
+		}
+		return nil, fmt.Errorf("'%s' is the prefix of %d UUIDs: %s", nameOrUUID, len(found), strings.Join(uuids, ", "))
+	}
+	return found, nil
+}
+
+// isUUIDPrefix reports whether s could be the start of a ZStack UUID, which
+// is 32 lowercase hex digits.
+func isUUIDPrefix(s string) bool {
+	if len(s) == 0 || len(s) > 32 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// FieldValue returns the value of a field of an inventory as a string.
+// Nested fields are separated by dots; a missing field yields "".
+func FieldValue(inventory map[string]interface{}, field string) string {
+	var current interface{} = inventory
+	for _, part := range strings.Split(field, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current, ok = m[part]
+		if !ok {
+			return ""
+		}
+	}
+	if current == nil {
+		return ""
+	}
+	if f, ok := current.(float64); ok && f == float64(int64(f)) {
+		return fmt.Sprintf("%d", int64(f))
+	}
+	return fmt.Sprintf("%v", current)
+}
+
+// FindResourcesByNamesOrUUIDs looks up each of identifiers with
+// FindResources and returns the matches without duplicates, in the order
+// they were named. keep, if not nil, filters the matches. An identifier
+// with no kept match is an error.
+func FindResourcesByNamesOrUUIDs(cli *sdkClient.ZSClient, path string, identifiers []string, keep func(map[string]interface{}) bool) ([]map[string]interface{}, error) {
+	seen := map[string]bool{}
+	var found []map[string]interface{}
+	var unmatched []string
+	for _, id := range identifiers {
+		matches, err := FindResources(cli, path, id)
+		if err != nil {
+			return nil, err
+		}
+		kept := 0
+		for _, inv := range matches {
+			if keep != nil && !keep(inv) {
+				continue
+			}
+			kept++
+			uuid := FieldValue(inv, "uuid")
+			if !seen[uuid] {
+				seen[uuid] = true
+				found = append(found, inv)
+			}
+		}
+		if kept == 0 {
+			unmatched = append(unmatched, id)
+		}
+	}
+	if len(unmatched) > 0 {
+		return nil, fmt.Errorf("no matching resources found for: %s", strings.Join(unmatched, ", "))
+	}
+	return found, nil
+}
+
+// FindTaggedResources returns the inventories under path among uuids that
+// carry the user tag tag.
+func FindTaggedResources(cli *sdkClient.ZSClient, path string, uuids []string, tag string) ([]map[string]interface{}, error) {
+	if len(uuids) == 0 {
+		return nil, nil
+	}
+	queryParam := param.NewQueryParam()
+	queryParam.AddQ(fmt.Sprintf("uuid?=%s", strings.Join(uuids, ",")))
+	queryParam.AddQ(fmt.Sprintf("__userTag__=%s", tag))
+	return QueryResources(cli, path, queryParam)
+}
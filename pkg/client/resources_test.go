@@ -0,0 +1,80 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import "testing"
+
+func TestFieldValue(t *testing.T) {
+	inventory := map[string]interface{}{
+		"uuid":      "abc",
+		"cpuNum":    float64(4),
+		"ratio":     1.5,
+		"big":       float64(1 << 40),
+		"enabled":   true,
+		"hostUuid":  nil,
+		"vmNics":    []interface{}{map[string]interface{}{"ip": "10.0.0.2"}},
+		"allocator": map[string]interface{}{"name": "default", "limits": map[string]interface{}{"memory": float64(2048)}},
+	}
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"uuid", "abc"},
+		{"cpuNum", "4"},
+		{"ratio", "1.5"},
+		{"big", "1099511627776"},
+		{"enabled", "true"},
+		{"hostUuid", ""},
+		{"missing", ""},
+		{"allocator.name", "default"},
+		{"allocator.limits.memory", "2048"},
+		{"allocator.missing", ""},
+		{"uuid.part", ""},
+		{"hostUuid.part", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if got := FieldValue(inventory, tt.field); got != tt.want {
+				t.Errorf("FieldValue(%q) = %q, want %q", tt.field, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsUUIDPrefix(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"", false},
+		{"a", true},
+		{"3f2a", true},
+		{"0123456789abcdef0123456789abcdef", true},
+		{"0123456789abcdef0123456789abcdef0", false},
+		{"3F2A", false},
+		{"web-1", false},
+		{"3f2a-", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := isUUIDPrefix(tt.s); got != tt.want {
+				t.Errorf("isUUIDPrefix(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
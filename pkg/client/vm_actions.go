@@ -0,0 +1,160 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+// VM actions the SDK does not wrap, or wraps with parameters that would
+// overwrite unrelated fields, are sent through the generic action API here.
+
+// UpdateVmCpuMemoryParams is the action sent by UpdateVmCpuMemory.
+func UpdateVmCpuMemoryParams(cpuNum *int, memorySize *int64) map[string]interface{} {
+	detail := map[string]interface{}{}
+	if cpuNum != nil {
+		detail["cpuNum"] = *cpuNum
+	}
+	if memorySize != nil {
+		detail["memorySize"] = *memorySize
+	}
+	return map[string]interface{}{"updateVmInstance": detail}
+}
+
+// UpdateVmCpuMemory changes the CPU count and/or memory size of a VM. Nil
+// values are left unchanged.
+func UpdateVmCpuMemory(cli *sdkClient.ZSClient, vmUUID string, cpuNum *int, memorySize *int64) (*view.VmInstanceInventoryView, error) {
+	var resp view.VmInstanceInventoryView
+	params := UpdateVmCpuMemoryParams(cpuNum, memorySize)
+	if err := cli.Put("v1/vm-instances", vmUUID, params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ChangeInstanceOfferingParams is the action sent by ChangeInstanceOffering.
+func ChangeInstanceOfferingParams(offeringUUID string) map[string]interface{} {
+	return map[string]interface{}{
+		"changeInstanceOffering": map[string]string{"instanceOfferingUuid": offeringUUID},
+	}
+}
+
+// ChangeInstanceOffering moves a VM to another instance offering.
+func ChangeInstanceOffering(cli *sdkClient.ZSClient, vmUUID, offeringUUID string) (*view.VmInstanceInventoryView, error) {
+	var resp view.VmInstanceInventoryView
+	params := ChangeInstanceOfferingParams(offeringUUID)
+	if err := cli.Put("v1/vm-instances", vmUUID, params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetVmDefaultL3NetworkParams is the action sent by SetVmDefaultL3Network.
+func SetVmDefaultL3NetworkParams(l3UUID string) map[string]interface{} {
+	return map[string]interface{}{
+		"updateVmInstance": map[string]string{"defaultL3NetworkUuid": l3UUID},
+	}
+}
+
+// SetVmDefaultL3Network makes l3UUID the default network of a VM.
+func SetVmDefaultL3Network(cli *sdkClient.ZSClient, vmUUID, l3UUID string) (*view.VmInstanceInventoryView, error) {
+	var resp view.VmInstanceInventoryView
+	params := SetVmDefaultL3NetworkParams(l3UUID)
+	if err := cli.Put("v1/vm-instances", vmUUID, params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetVmStaticIpParams is the action sent by SetVmStaticIp.
+func SetVmStaticIpParams(l3UUID, ip string) map[string]interface{} {
+	return map[string]interface{}{
+		"setVmStaticIp": map[string]string{"l3NetworkUuid": l3UUID, "ip": ip},
+	}
+}
+
+// SetVmStaticIp pins the IPv4 address of the VM's NIC on l3UUID. The SDK
+// parameter always sends an empty ip6, so the payload is built here.
+func SetVmStaticIp(cli *sdkClient.ZSClient, vmUUID, l3UUID, ip string) error {
+	return cli.Put("v1/vm-instances", vmUUID, SetVmStaticIpParams(l3UUID, ip), nil)
+}
+
+// SetVmSshKeyParams is the action sent by SetVmSshKey.
+func SetVmSshKeyParams(sshKey string) map[string]interface{} {
+	return map[string]interface{}{
+		"setVmSshKey": map[string]string{"sshKey": sshKey},
+	}
+}
+
+// SetVmSshKey injects an SSH public key into a VM. The SDK parameter
+// serializes the key as "SshKey", which the API does not recognise.
+func SetVmSshKey(cli *sdkClient.ZSClient, vmUUID, sshKey string) error {
+	return cli.Put("v1/vm-instances", vmUUID, SetVmSshKeyParams(sshKey), nil)
+}
+
+// ReimageVmInstance rebuilds the root volume of a stopped VM from the image
+// it was created from.
+func ReimageVmInstance(cli *sdkClient.ZSClient, vmUUID string) (*view.VmInstanceInventoryView, error) {
+	var resp view.VmInstanceInventoryView
+	params := map[string]interface{}{"reimageVmInstance": map[string]string{}}
+	if err := cli.Put("v1/vm-instances", vmUUID, params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ChangeVmImageParams is the action sent by ChangeVmImage.
+func ChangeVmImageParams(imageUUID string) map[string]interface{} {
+	return map[string]interface{}{
+		"changeVmImage": map[string]string{"imageUuid": imageUUID},
+	}
+}
+
+// ChangeVmImage rebuilds the root volume of a stopped VM from another
+// image.
+func ChangeVmImage(cli *sdkClient.ZSClient, vmUUID, imageUUID string) (*view.VmInstanceInventoryView, error) {
+	var resp view.VmInstanceInventoryView
+	params := ChangeVmImageParams(imageUUID)
+	if err := cli.Put("v1/vm-instances", vmUUID, params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// IsVmHotPlugEnabled reports whether CPU and memory can be added to running
+// VMs, which ZStack controls with the vm.numa global config.
+func IsVmHotPlugEnabled(cli *sdkClient.ZSClient) (bool, error) {
+	queryParam := param.NewQueryParam()
+	queryParam.AddQ("category=vm")
+	queryParam.AddQ("name=numa")
+
+	configs, err := cli.QueryGlobalConfig(queryParam)
+	if err != nil {
+		return false, err
+	}
+	return len(configs) > 0 && configs[0].Value == "true", nil
+}
+
+// RecoverVmInstance brings a Destroyed VM back. The VM is Stopped afterwards.
+func RecoverVmInstance(cli *sdkClient.ZSClient, vmUUID string) (*view.VmInstanceInventoryView, error) {
+	var resp view.VmInstanceInventoryView
+	params := map[string]interface{}{"recoverVmInstance": map[string]string{}}
+	if err := cli.Put("v1/vm-instances", vmUUID, params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
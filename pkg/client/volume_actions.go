@@ -0,0 +1,51 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+// CreateDataVolumeParams is the body sent by CreateDataVolume. The SDK
+// parameter struct always sends diskOfferingUuid and primaryStorageUuid,
+// which the API rejects when they are empty, so only the fields that are set
+// are sent.
+func CreateDataVolumeParams(name, description string, diskSize int64, diskOfferingUUID, primaryStorageUUID string) map[string]interface{} {
+	detail := map[string]interface{}{"name": name}
+	if description != "" {
+		detail["description"] = description
+	}
+	if diskSize > 0 {
+		detail["diskSize"] = diskSize
+	}
+	if diskOfferingUUID != "" {
+		detail["diskOfferingUuid"] = diskOfferingUUID
+	}
+	if primaryStorageUUID != "" {
+		detail["primaryStorageUuid"] = primaryStorageUUID
+	}
+	return map[string]interface{}{"params": detail}
+}
+
+// CreateDataVolume creates an unattached data volume.
+func CreateDataVolume(cli *sdkClient.ZSClient, name, description string, diskSize int64, diskOfferingUUID, primaryStorageUUID string) (*view.VolumeView, error) {
+	var resp view.VolumeView
+	params := CreateDataVolumeParams(name, description, diskSize, diskOfferingUUID, primaryStorageUUID)
+	if err := cli.Post("v1/volumes/data", params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
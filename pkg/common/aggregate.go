@@ -0,0 +1,110 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+// CountResult is the structured output of --count.
+type CountResult struct {
+	Total int `json:"total" yaml:"total"`
+}
+
+// GroupResult is the structured output of --group-by.
+type GroupResult struct {
+	GroupBy string              `json:"groupBy" yaml:"groupBy"`
+	Total   int                 `json:"total" yaml:"total"`
+	Groups  []client.GroupCount `json:"groups" yaml:"groups"`
+}
+
+// RunAggregateQuery serves --count and --group-by for a get command whose
+// resources live under resource (for example "v1/vm-instances"). It reports
+// whether either flag was set, in which case the command must not go on to
+// list rows.
+func RunAggregateQuery(cmd *cobra.Command, zsClient *sdkClient.ZSClient, queryParam *param.QueryParam, resource string) bool {
+	count, _ := cmd.Flags().GetBool("count")
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	if !count && groupBy == "" {
+		return false
+	}
+
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+
+	if groupBy != "" {
+		groups, err := client.CountResourcesBy(zsClient, resource, *queryParam, groupBy)
+		if err != nil {
+			fmt.Printf("Error grouping results by %s: %s\n", groupBy, err)
+			return true
+		}
+
+		total := 0
+		for _, g := range groups {
+			total += g.Count
+		}
+
+		if utils.IsStructuredFormat(format) {
+			err = utils.Print(GroupResult{GroupBy: groupBy, Total: total, Groups: groups}, format)
+		} else {
+			err = utils.Print(groups, format)
+			fmt.Printf("Total: %d\n", total)
+		}
+		if err != nil {
+			fmt.Printf("Error formatting output: %s\n", err)
+		}
+		return true
+	}
+
+	total, err := client.CountResources(zsClient, resource, *queryParam)
+	if err != nil {
+		fmt.Printf("Error counting results: %s\n", err)
+		return true
+	}
+
+	if utils.IsStructuredFormat(format) {
+		if err := utils.Print(CountResult{Total: total}, format); err != nil {
+			fmt.Printf("Error formatting output: %s\n", err)
+		}
+		return true
+	}
+	fmt.Printf("Total: %d\n", total)
+	return true
+}
+
+// PrintReplyCount prints the total reported by the server when
+// --reply-with-count is set. It must be called after the query has run, as
+// the SDK records the total in queryParam.
+func PrintReplyCount(cmd *cobra.Command, queryParam *param.QueryParam) {
+	replyWithCount, _ := cmd.Flags().GetBool("reply-with-count")
+	if !replyWithCount {
+		return
+	}
+
+	total, err := strconv.Atoi(queryParam.Get("total"))
+	if err != nil {
+		return
+	}
+
+	outputFormat, _ := cmd.Flags().GetString("output")
+	fmt.Fprintf(utils.StatusWriter(utils.ParseFormat(outputFormat)), "Total: %d\n", total)
+}
@@ -0,0 +1,280 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+// skipReasonFailFast is recorded for items that were not attempted because
+// an earlier one failed without --continue-on-error.
+const skipReasonFailFast = "not attempted after an earlier failure"
+
+func AddBatchFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("parallel", 1, "Number of resources processed at the same time")
+	cmd.Flags().Duration("item-timeout", 0, "Maximum time spent on each resource, including --wait (0 means no limit)")
+	cmd.Flags().Bool("continue-on-error", true, "Keep going after a resource fails; set to false to stop at the first failure")
+}
+
+// BatchOptions controls how RunBatch processes items.
+type BatchOptions struct {
+	Parallel        int
+	ItemTimeout     time.Duration
+	ContinueOnError bool
+}
+
+// BatchOptionsFromFlags reads the flags added by AddBatchFlags.
+func BatchOptionsFromFlags(cmd *cobra.Command) (BatchOptions, error) {
+	parallel, _ := cmd.Flags().GetInt("parallel")
+	itemTimeout, _ := cmd.Flags().GetDuration("item-timeout")
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+
+	if parallel < 1 {
+		return BatchOptions{}, fmt.Errorf("--parallel must be at least 1, got %d", parallel)
+	}
+	if itemTimeout < 0 {
+		return BatchOptions{}, fmt.Errorf("--item-timeout must not be negative, got %s", itemTimeout)
+	}
+	return BatchOptions{Parallel: parallel, ItemTimeout: itemTimeout, ContinueOnError: continueOnError}, nil
+}
+
+// BatchFunc performs an operation on one VM and returns its updated
+// inventory. Progress, including the success message, is written to w.
+type BatchFunc func(vm sdkView.VmInstanceInventoryView, w io.Writer) (*sdkView.VmInstanceInventoryView, error)
+
+// ItemTimeoutError is returned for an item that exceeded --item-timeout.
+type ItemTimeoutError struct {
+	After time.Duration
+}
+
+func (e *ItemTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s", e.After)
+}
+
+func (e *ItemTimeoutError) Timeout() bool { return true }
+
+// BatchError is returned by RunBatch when some items failed, so that the
+// command exits non-zero after printing its summary.
+type BatchError struct {
+	Operation string
+	Total     int
+	Failed    int
+	// NotAttempted counts the items skipped after a failure without
+	// --continue-on-error.
+	NotAttempted int
+}
+
+func (e *BatchError) Error() string {
+	msg := fmt.Sprintf("failed to %s %d of %d", e.Operation, e.Failed, e.Total)
+	if e.NotAttempted > 0 {
+		msg += fmt.Sprintf(", %d not attempted", e.NotAttempted)
+	}
+	return msg
+}
+
+// BatchRecorder collects the outcome of each item of a batch.
+// utils.BatchResult records VMs and utils.ResourceBatchResult records
+// generic inventories.
+type BatchRecorder[T any] interface {
+	Succeed(item T)
+	Fail(item T, err error)
+	Skip(item T, reason string)
+}
+
+// batchItem is the outcome of one item. Its output is buffered when items
+// run in parallel and printed once every earlier item has been printed.
+type batchItem[T any] struct {
+	value T
+	out   *itemWriter
+	resp  *T
+	err   error
+	skip  string
+	done  bool
+}
+
+// RunBatch runs op on each VM, at most opts.Parallel at a time, and records
+// the outcomes in result in the order of vms. Output of each VM is printed
+// to w in the same order. Without opts.ContinueOnError no VM is started
+// after one has failed, and those left over are recorded as skipped. If any
+// VM failed the returned error is a *BatchError.
+func RunBatch(opts BatchOptions, w io.Writer, result *utils.BatchResult, vms []sdkView.VmInstanceInventoryView, op BatchFunc) error {
+	label := func(vm sdkView.VmInstanceInventoryView) string {
+		return fmt.Sprintf("%s (%s)", vm.Name, vm.UUID)
+	}
+	return RunBatchItems(opts, w, result.Operation, vms, label, op, result)
+}
+
+// RunBatchItems is RunBatch for any kind of item. label names an item in
+// failure messages, which read "Failed to <operation> <label>".
+func RunBatchItems[T any](opts BatchOptions, w io.Writer, operation string, values []T, label func(T) string, op func(T, io.Writer) (*T, error), result BatchRecorder[T]) error {
+	items := make([]*batchItem[T], len(values))
+	for i, v := range values {
+		items[i] = &batchItem[T]{value: v, out: newItemWriter(w, opts.Parallel > 1)}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	next := 0
+	failed := false
+	batchErr := &BatchError{Operation: operation, Total: len(values)}
+
+	// flush prints and records finished items up to the first unfinished
+	// one. mu must be held.
+	flush := func() {
+		for ; next < len(items) && items[next].done; next++ {
+			item := items[next]
+			item.out.flush()
+			switch {
+			case item.skip != "":
+				batchErr.NotAttempted++
+				result.Skip(item.value, item.skip)
+			case item.err != nil:
+				batchErr.Failed++
+				result.Fail(item.value, item.err)
+			default:
+				result.Succeed(*item.resp)
+			}
+		}
+	}
+
+	sem := make(chan struct{}, opts.Parallel)
+	for _, item := range items {
+		sem <- struct{}{}
+
+		mu.Lock()
+		if failed && !opts.ContinueOnError {
+			item.skip = skipReasonFailFast
+			item.done = true
+			flush()
+			mu.Unlock()
+			<-sem
+			continue
+		}
+		mu.Unlock()
+
+		wg.Add(1)
+		go func(item *batchItem[T]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := runBatchItem(opts.ItemTimeout, item.value, item.out, op)
+			final := ""
+			if err != nil {
+				final = fmt.Sprintf("Failed to %s %s: %v\n", operation, label(item.value), err)
+			}
+			item.out.close(final)
+
+			mu.Lock()
+			defer mu.Unlock()
+			item.resp, item.err, item.done = resp, err, true
+			if err != nil {
+				failed = true
+			}
+			flush()
+		}(item)
+	}
+	wg.Wait()
+
+	if batchErr.Failed > 0 {
+		return batchErr
+	}
+	return nil
+}
+
+func runBatchItem[T any](timeout time.Duration, value T, w io.Writer, op func(T, io.Writer) (*T, error)) (*T, error) {
+	if timeout <= 0 {
+		return op(value, w)
+	}
+
+	type reply struct {
+		resp *T
+		err  error
+	}
+	replies := make(chan reply, 1)
+	go func() {
+		resp, err := op(value, w)
+		replies <- reply{resp, err}
+	}()
+
+	select {
+	case r := <-replies:
+		return r.resp, r.err
+	case <-time.After(timeout):
+		// The request keeps running in the background; its output is
+		// dropped once the item writer is closed.
+		return nil, &ItemTimeoutError{After: timeout}
+	}
+}
+
+// itemWriter collects the output of one VM. Unbuffered writers pass output
+// straight through. Writes after close are dropped, so an operation that
+// outlived --item-timeout cannot print out of order.
+type itemWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	buffered bool
+	buf      bytes.Buffer
+	closed   bool
+}
+
+func newItemWriter(w io.Writer, buffered bool) *itemWriter {
+	return &itemWriter{w: w, buffered: buffered}
+}
+
+func (iw *itemWriter) Write(p []byte) (int, error) {
+	iw.mu.Lock()
+	defer iw.mu.Unlock()
+	if iw.closed {
+		return len(p), nil
+	}
+	if iw.buffered {
+		return iw.buf.Write(p)
+	}
+	return iw.w.Write(p)
+}
+
+// close drops further writes and then writes the final message, if any.
+func (iw *itemWriter) close(final string) {
+	iw.mu.Lock()
+	iw.closed = true
+	iw.mu.Unlock()
+
+	if final == "" {
+		return
+	}
+	if iw.buffered {
+		iw.buf.WriteString(final)
+	} else {
+		io.WriteString(iw.w, final)
+	}
+}
+
+// flush prints the buffered output.
+func (iw *itemWriter) flush() {
+	iw.mu.Lock()
+	defer iw.mu.Unlock()
+	if iw.buf.Len() > 0 {
+		iw.w.Write(iw.buf.Bytes())
+		iw.buf.Reset()
+	}
+}
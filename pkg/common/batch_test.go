@@ -0,0 +1,196 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder records batch outcomes as strings in the order they arrive.
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) Succeed(item string) { r.events = append(r.events, "ok "+item) }
+func (r *recorder) Fail(item string, err error) {
+	r.events = append(r.events, "fail "+item+": "+err.Error())
+}
+func (r *recorder) Skip(item string, reason string) {
+	r.events = append(r.events, "skip "+item+": "+reason)
+}
+
+// syncBuffer is a bytes.Buffer that can be written from several goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// batchStep describes how the test operation handles one item.
+type batchStep struct {
+	delay time.Duration
+	err   error
+}
+
+func TestRunBatchItems(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name       string
+		opts       BatchOptions
+		steps      map[string]batchStep
+		wantEvents []string
+		wantOutput string
+		wantCalls  []string
+		wantErr    string
+	}{
+		{
+			name:       "sequential",
+			opts:       BatchOptions{Parallel: 1, ContinueOnError: true},
+			wantEvents: []string{"ok a", "ok b", "ok c"},
+			wantOutput: "done a\ndone b\ndone c\n",
+			wantCalls:  []string{"a", "b", "c"},
+		},
+		{
+			name: "parallel keeps input order",
+			opts: BatchOptions{Parallel: 3, ContinueOnError: true},
+			steps: map[string]batchStep{
+				"a": {delay: 60 * time.Millisecond},
+				"b": {delay: 30 * time.Millisecond},
+			},
+			wantEvents: []string{"ok a", "ok b", "ok c"},
+			wantOutput: "done a\ndone b\ndone c\n",
+			wantCalls:  []string{"a", "b", "c"},
+		},
+		{
+			name:       "continue on error",
+			opts:       BatchOptions{Parallel: 1, ContinueOnError: true},
+			steps:      map[string]batchStep{"b": {err: errBoom}},
+			wantEvents: []string{"ok a", "fail b: boom", "ok c"},
+			wantOutput: "done a\nFailed to test b: boom\ndone c\n",
+			wantCalls:  []string{"a", "b", "c"},
+			wantErr:    "failed to test 1 of 3",
+		},
+		{
+			name:       "fail fast",
+			opts:       BatchOptions{Parallel: 1},
+			steps:      map[string]batchStep{"b": {err: errBoom}},
+			wantEvents: []string{"ok a", "fail b: boom", "skip c: " + skipReasonFailFast},
+			wantOutput: "done a\nFailed to test b: boom\n",
+			wantCalls:  []string{"a", "b"},
+			wantErr:    "failed to test 1 of 3, 1 not attempted",
+		},
+		{
+			name:       "item timeout",
+			opts:       BatchOptions{Parallel: 1, ItemTimeout: 20 * time.Millisecond, ContinueOnError: true},
+			steps:      map[string]batchStep{"b": {delay: time.Second}},
+			wantEvents: []string{"ok a", "fail b: timed out after 20ms", "ok c"},
+			wantOutput: "done a\nFailed to test b: timed out after 20ms\ndone c\n",
+			wantCalls:  []string{"a", "b", "c"},
+			wantErr:    "failed to test 1 of 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var calls []string
+			op := func(item string, w io.Writer) (*string, error) {
+				mu.Lock()
+				calls = append(calls, item)
+				mu.Unlock()
+
+				step := tt.steps[item]
+				time.Sleep(step.delay)
+				if step.err != nil {
+					return nil, step.err
+				}
+				fmt.Fprintf(w, "done %s\n", item)
+				return &item, nil
+			}
+
+			var out syncBuffer
+			rec := &recorder{}
+			err := RunBatchItems(tt.opts, &out, "test", []string{"a", "b", "c"}, func(s string) string { return s }, op, rec)
+
+			var batchErr *BatchError
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (!errors.As(err, &batchErr) || err.Error() != tt.wantErr) {
+				t.Errorf("error = %v, want a BatchError %q", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(rec.events, tt.wantEvents) {
+				t.Errorf("events = %q, want %q", rec.events, tt.wantEvents)
+			}
+			if got := out.String(); got != tt.wantOutput {
+				t.Errorf("output = %q, want %q", got, tt.wantOutput)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if tt.opts.Parallel == 1 && !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("calls = %q, want %q", calls, tt.wantCalls)
+			}
+			if len(calls) != len(tt.wantCalls) {
+				t.Errorf("%d items attempted, want %d", len(calls), len(tt.wantCalls))
+			}
+		})
+	}
+}
+
+func TestRunBatchItemsTimeoutError(t *testing.T) {
+	rec := &recorder{}
+	var failure error
+	op := func(item string, w io.Writer) (*string, error) {
+		time.Sleep(time.Second)
+		return &item, nil
+	}
+	RunBatchItems(BatchOptions{Parallel: 1, ItemTimeout: 10 * time.Millisecond}, io.Discard, "test", []string{"a"},
+		func(s string) string { return s }, op, failRecorder{rec, &failure})
+
+	var timeout *ItemTimeoutError
+	if !errors.As(failure, &timeout) || timeout.After != 10*time.Millisecond {
+		t.Fatalf("failure = %v, want an ItemTimeoutError after 10ms", failure)
+	}
+}
+
+// failRecorder keeps the error of the last failed item.
+type failRecorder struct {
+	*recorder
+	err *error
+}
+
+func (r failRecorder) Fail(item string, err error) {
+	*r.err = err
+	r.recorder.Fail(item, err)
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"golang.org/x/term"
+)
+
+// TypedConfirmThreshold is the number of resources above which a
+// destructive operation must be confirmed by typing the action and count
+// instead of yes. Typing every name would be impractical for that many, so
+// the phrase, e.g. "delete 12", stands in for the names listed above it.
+const TypedConfirmThreshold = 10
+
+// ProtectedTag is the user tag that makes delete, expunge and stop refuse
+// a resource unless --force is given.
+const ProtectedTag = "protected"
+
+// AddConfirmFlags adds --yes and --force to cmd and its subcommands.
+func AddConfirmFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolP("yes", "y", false, "Automatic yes to prompts")
+	cmd.PersistentFlags().Bool("force", false, forceUsage)
+}
+
+// AddForceFlag adds --force to cmd alone.
+func AddForceFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("force", false, forceUsage)
+}
+
+var forceUsage = fmt.Sprintf("Also act on resources with the %q user tag", ProtectedTag)
+
+// Confirmation describes an operation that needs the user's consent.
+type Confirmation struct {
+	// Action is the verb shown in prompts, e.g. "delete".
+	Action string
+	// Kind names the resources, e.g. "VM instances".
+	Kind  string
+	Count int
+	// Names of the resources, which the caller lists before asking. A
+	// destructive operation on a single named resource is confirmed by
+	// typing its name.
+	Names []string
+	// Destructive operations must be confirmed by typing the name of the
+	// resource, yes when several are affected, or the action and count when
+	// more than TypedConfirmThreshold are.
+	Destructive bool
+}
+
+// prompt returns the question to ask for c and the answers that accept it.
+// Answers are compared case-insensitively unless exact is set.
+func (c Confirmation) prompt() (question string, accepted []string, exact bool) {
+	switch {
+	case c.Destructive && c.Count > TypedConfirmThreshold:
+		typed := fmt.Sprintf("%s %d", c.Action, c.Count)
+		return fmt.Sprintf("This will %s %d %s. Type '%s' to confirm: ", c.Action, c.Count, c.Kind, typed), []string{typed}, false
+	case c.Destructive && c.Count == 1 && len(c.Names) == 1 && c.Names[0] != "":
+		return fmt.Sprintf("Type the name '%s' to confirm: ", c.Names[0]), c.Names, true
+	case c.Destructive:
+		return fmt.Sprintf("Are you sure you want to %s the above %s? Type 'yes' to confirm: ", c.Action, c.Kind), []string{"yes"}, false
+	default:
+		return "Do you want to continue? [y/N]: ", []string{"y", "yes"}, false
+	}
+}
+
+// Confirm asks the user to confirm c unless --yes is set. It returns false
+// if the user declines, and an error if stdin is not a terminal, so a
+// script never hangs on a prompt or goes ahead by accident.
+func Confirm(cmd *cobra.Command, w io.Writer, c Confirmation) (bool, error) {
+	autoYes, _ := cmd.Flags().GetBool("yes")
+	if autoYes {
+		return true, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("refusing to %s %s without confirmation: stdin is not a terminal, pass --yes to go ahead", c.Action, c.Kind)
+	}
+
+	question, accepted, exact := c.prompt()
+	fmt.Fprint(w, question)
+
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if !acceptsAnswer(accepted, line, exact) {
+		fmt.Fprintln(w, "Aborted by user.")
+		return false, nil
+	}
+	return true, nil
+}
+
+// acceptsAnswer reports whether the line typed by the user is one of
+// accepted.
+func acceptsAnswer(accepted []string, line string, exact bool) bool {
+	line = strings.TrimSpace(line)
+	if !exact {
+		line = strings.ToLower(line)
+	}
+	return containsString(accepted, line)
+}
+
+// CheckProtected returns an error listing the resources under path among
+// uuids that carry ProtectedTag, unless --force is set. With --dry-run the
+// refusal is only reported to w, so the dry run still shows its requests.
+func CheckProtected(cmd *cobra.Command, w io.Writer, cli *sdkClient.ZSClient, path string, uuids []string, action string) error {
+	force, _ := cmd.Flags().GetBool("force")
+	if force {
+		return nil
+	}
+	protected, err := client.FindTaggedResources(cli, path, uuids, ProtectedTag)
+	if err != nil {
+		return fmt.Errorf("check for %q tags failed: %s", ProtectedTag, err)
+	}
+	if len(protected) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(protected))
+	for _, inv := range protected {
+		names = append(names, fmt.Sprintf("%s (%s)", client.FieldValue(inv, "name"), client.FieldValue(inv, "uuid")))
+	}
+	err = fmt.Errorf("refusing to %s resources tagged %q, pass --force to override: %s", action, ProtectedTag, strings.Join(names, ", "))
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		fmt.Fprintf(w, "Warning: without --dry-run this would fail: %v\n", err)
+		return nil
+	}
+	return err
+}
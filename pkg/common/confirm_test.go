@@ -0,0 +1,103 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfirmationPrompt(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        Confirmation
+		question string
+		accepted []string
+		exact    bool
+	}{
+		{
+			name:     "not destructive",
+			c:        Confirmation{Action: "stop", Kind: "VM instances", Count: 3},
+			question: "Do you want to continue? [y/N]: ",
+			accepted: []string{"y", "yes"},
+		},
+		{
+			name:     "single named resource",
+			c:        Confirmation{Action: "delete", Kind: "images", Count: 1, Names: []string{"Old-Image"}, Destructive: true},
+			question: "Type the name 'Old-Image' to confirm: ",
+			accepted: []string{"Old-Image"},
+			exact:    true,
+		},
+		{
+			name:     "single resource without a name",
+			c:        Confirmation{Action: "delete", Kind: "tags", Count: 1, Names: []string{""}, Destructive: true},
+			question: "Are you sure you want to delete the above tags? Type 'yes' to confirm: ",
+			accepted: []string{"yes"},
+		},
+		{
+			name:     "several resources",
+			c:        Confirmation{Action: "expunge", Kind: "volumes", Count: 2, Names: []string{"a", "b"}, Destructive: true},
+			question: "Are you sure you want to expunge the above volumes? Type 'yes' to confirm: ",
+			accepted: []string{"yes"},
+		},
+		{
+			name:     "more than the threshold",
+			c:        Confirmation{Action: "delete", Kind: "VM instances", Count: TypedConfirmThreshold + 1, Destructive: true},
+			question: "This will delete 11 VM instances. Type 'delete 11' to confirm: ",
+			accepted: []string{"delete 11"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question, accepted, exact := tt.c.prompt()
+			if question != tt.question {
+				t.Errorf("question = %q, want %q", question, tt.question)
+			}
+			if !reflect.DeepEqual(accepted, tt.accepted) {
+				t.Errorf("accepted = %q, want %q", accepted, tt.accepted)
+			}
+			if exact != tt.exact {
+				t.Errorf("exact = %v, want %v", exact, tt.exact)
+			}
+		})
+	}
+}
+
+func TestAcceptsAnswer(t *testing.T) {
+	tests := []struct {
+		name     string
+		accepted []string
+		line     string
+		exact    bool
+		want     bool
+	}{
+		{"yes", []string{"y", "yes"}, "YES\n", false, true},
+		{"empty", []string{"y", "yes"}, "\n", false, false},
+		{"count phrase", []string{"delete 11"}, " Delete 11 \n", false, true},
+		{"wrong count", []string{"delete 11"}, "delete 12\n", false, false},
+		{"name", []string{"Old-Image"}, "Old-Image\n", true, true},
+		{"name in other case", []string{"Old-Image"}, "old-image\n", true, false},
+		{"yes instead of name", []string{"Old-Image"}, "yes\n", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := acceptsAnswer(tt.accepted, tt.line, tt.exact); got != tt.want {
+				t.Errorf("acceptsAnswer(%q, %q) = %v, want %v", tt.accepted, tt.line, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+// FilterType selects how a filter flag is validated and compiled.
+type FilterType int
+
+const (
+	// FilterEnum matches one or more comma-separated values, e.g. --state Running,Stopped.
+	FilterEnum FilterType = iota
+	// FilterSize adds --<name>-gt and --<name>-lt taking sizes such as 8G.
+	FilterSize
+	// FilterTime adds --<name>-after and --<name>-before taking dates or timestamps.
+	FilterTime
+	// FilterPattern matches a value that may contain * wildcards, e.g. --ip 10.0.0.*.
+	FilterPattern
+	// FilterLookup resolves a name or UUID with Lookup and matches the UUID.
+	FilterLookup
+)
+
+// FilterFlag declares a typed filter flag and the query field it compiles to.
+type FilterFlag struct {
+	Name  string
+	Field string
+	Type  FilterType
+	Usage string
+
+	// Values optionally restricts a FilterEnum to known values. Matching is
+	// case-insensitive and the canonical spelling is sent to the server.
+	Values []string
+
+	// Lookup resolves the flag value of a FilterLookup to a UUID.
+	Lookup func(cli *sdkClient.ZSClient, nameOrUUID string) (string, error)
+}
+
+// queryTimeFormat is the timestamp layout used in ZStack query conditions.
+const queryTimeFormat = "2006-01-02 15:04:05"
+
+var filterTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// filterFlags holds the filters registered per command, for BuildQueryParams.
+var filterFlags = map[*cobra.Command][]FilterFlag{}
+
+// AddFilterFlags registers typed filter flags on cmd. BuildQueryParams
+// validates them and compiles them into query conditions.
+func AddFilterFlags(cmd *cobra.Command, filters ...FilterFlag) {
+	for _, f := range filters {
+		switch f.Type {
+		case FilterSize:
+			cmd.Flags().String(f.Name+"-gt", "", fmt.Sprintf("Only show resources with %s greater than this (e.g. 8G, 512M)", f.Usage))
+			cmd.Flags().String(f.Name+"-lt", "", fmt.Sprintf("Only show resources with %s less than this (e.g. 8G, 512M)", f.Usage))
+		case FilterTime:
+			cmd.Flags().String(f.Name+"-after", "", fmt.Sprintf("Only show resources %s after this date (e.g. 2025-01-01 or 2025-01-01T08:00:00Z)", f.Usage))
+			cmd.Flags().String(f.Name+"-before", "", fmt.Sprintf("Only show resources %s before this date (e.g. 2025-01-01 or 2025-01-01T08:00:00Z)", f.Usage))
+		case FilterEnum:
+			usage := fmt.Sprintf("Filter by %s, comma-separated for several", f.Usage)
+			if len(f.Values) > 0 {
+				usage += fmt.Sprintf(" (%s)", strings.Join(f.Values, ", "))
+			}
+			cmd.Flags().String(f.Name, "", usage)
+		case FilterPattern:
+			cmd.Flags().String(f.Name, "", fmt.Sprintf("Filter by %s, * matches any characters", f.Usage))
+		case FilterLookup:
+			cmd.Flags().String(f.Name, "", fmt.Sprintf("Filter by %s name or UUID", f.Usage))
+		}
+	}
+	filterFlags[cmd] = append(filterFlags[cmd], filters...)
+}
+
+// CreatedFilter filters by creation time with --created-after and --created-before.
+func CreatedFilter() FilterFlag {
+	return FilterFlag{Name: "created", Field: "createDate", Type: FilterTime, Usage: "created"}
+}
+
+// addFilterConditions validates the filter flags set on cmd and adds the
+// conditions they compile to to queryParam.
+func addFilterConditions(cmd *cobra.Command, queryParam *param.QueryParam) error {
+	var conditions []string
+	for _, f := range filterFlags[cmd] {
+		switch f.Type {
+		case FilterSize:
+			for _, bound := range []struct{ suffix, op string }{{"-gt", ">"}, {"-lt", "<"}} {
+				suffix, op := bound.suffix, bound.op
+				value, _ := cmd.Flags().GetString(f.Name + suffix)
+				if value == "" {
+					continue
+				}
+				size, err := utils.ParseMemorySize(value)
+				if err != nil {
+					return fmt.Errorf("invalid --%s%s: %v", f.Name, suffix, err)
+				}
+				conditions = append(conditions, fmt.Sprintf("%s%s%d", f.Field, op, size))
+			}
+		case FilterTime:
+			for _, bound := range []struct{ suffix, op string }{{"-after", ">"}, {"-before", "<"}} {
+				suffix, op := bound.suffix, bound.op
+				value, _ := cmd.Flags().GetString(f.Name + suffix)
+				if value == "" {
+					continue
+				}
+				t, err := parseFilterTime(value)
+				if err != nil {
+					return fmt.Errorf("invalid --%s%s: %v", f.Name, suffix, err)
+				}
+				conditions = append(conditions, fmt.Sprintf("%s%s%s", f.Field, op, t.Format(queryTimeFormat)))
+			}
+		case FilterEnum:
+			value, _ := cmd.Flags().GetString(f.Name)
+			if value == "" {
+				continue
+			}
+			values, err := normalizeEnumValues(f, value)
+			if err != nil {
+				return err
+			}
+			if len(values) == 1 {
+				conditions = append(conditions, fmt.Sprintf("%s=%s", f.Field, values[0]))
+			} else {
+				conditions = append(conditions, fmt.Sprintf("%s?=%s", f.Field, strings.Join(values, ",")))
+			}
+		case FilterPattern:
+			value, _ := cmd.Flags().GetString(f.Name)
+			if value == "" {
+				continue
+			}
+			if strings.Contains(value, "*") {
+				conditions = append(conditions, fmt.Sprintf("%s~=%s", f.Field, strings.ReplaceAll(value, "*", "%")))
+			} else {
+				conditions = append(conditions, fmt.Sprintf("%s=%s", f.Field, value))
+			}
+		case FilterLookup:
+			value, _ := cmd.Flags().GetString(f.Name)
+			if value == "" {
+				continue
+			}
+			zsClient := client.GetClient()
+			if zsClient == nil {
+				return fmt.Errorf("not logged in, please run 'zstack-cli login' first")
+			}
+			uuid, err := f.Lookup(zsClient, value)
+			if err != nil {
+				return fmt.Errorf("failed to find %s '%s': %v", f.Usage, value, err)
+			}
+			conditions = append(conditions, fmt.Sprintf("%s=%s", f.Field, uuid))
+		}
+	}
+
+	for _, c := range conditions {
+		queryParam.AddQ(c)
+	}
+	return nil
+}
+
+func normalizeEnumValues(f FilterFlag, value string) ([]string, error) {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if len(f.Values) == 0 {
+			values = append(values, v)
+			continue
+		}
+
+		canonical := ""
+		for _, known := range f.Values {
+			if strings.EqualFold(v, known) {
+				canonical = known
+				break
+			}
+		}
+		if canonical == "" {
+			return nil, fmt.Errorf("invalid --%s value '%s', must be one of: %s", f.Name, v, strings.Join(f.Values, ", "))
+		}
+		values = append(values, canonical)
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("--%s requires at least one value", f.Name)
+	}
+	return values, nil
+}
+
+func parseFilterTime(value string) (time.Time, error) {
+	for _, layout := range filterTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t.Local(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date '%s', use YYYY-MM-DD or RFC 3339", value)
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+// testFilters covers each filter type that compiles without a client.
+var testFilters = []FilterFlag{
+	{Name: "state", Field: "state", Type: FilterEnum, Usage: "state", Values: []string{"Running", "Stopped"}},
+	{Name: "hypervisor", Field: "hypervisorType", Type: FilterEnum, Usage: "hypervisor"},
+	{Name: "memory", Field: "memorySize", Type: FilterSize, Usage: "memory"},
+	{Name: "ip", Field: "vmNics.ip", Type: FilterPattern, Usage: "IP address"},
+	CreatedFilter(),
+}
+
+func compileFilters(t *testing.T, flags map[string]string) ([]string, error) {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	AddFilterFlags(cmd, testFilters...)
+	t.Cleanup(func() { delete(filterFlags, cmd) })
+	for name, value := range flags {
+		if err := cmd.Flags().Set(name, value); err != nil {
+			t.Fatalf("set --%s: %v", name, err)
+		}
+	}
+
+	queryParam := param.NewQueryParam()
+	if err := addFilterConditions(cmd, &queryParam); err != nil {
+		return nil, err
+	}
+	return queryParam.Values["q"], nil
+}
+
+func localQueryTime(t *testing.T, layout, value string) string {
+	t.Helper()
+	parsed, err := time.ParseInLocation(layout, value, time.Local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed.Local().Format(queryTimeFormat)
+}
+
+func TestAddFilterConditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    []string
+		wantErr string
+	}{
+		{name: "no flags"},
+		{
+			name:  "enum single value uses canonical spelling",
+			flags: map[string]string{"state": "running"},
+			want:  []string{"state=Running"},
+		},
+		{
+			name:  "enum several values",
+			flags: map[string]string{"state": "Running, stopped"},
+			want:  []string{"state?=Running,Stopped"},
+		},
+		{
+			name:  "enum without known values",
+			flags: map[string]string{"hypervisor": "KVM"},
+			want:  []string{"hypervisorType=KVM"},
+		},
+		{
+			name:    "enum unknown value",
+			flags:   map[string]string{"state": "Paused"},
+			wantErr: "invalid --state value 'Paused', must be one of: Running, Stopped",
+		},
+		{
+			name:    "enum empty list",
+			flags:   map[string]string{"state": " , "},
+			wantErr: "--state requires at least one value",
+		},
+		{
+			name:  "size bounds",
+			flags: map[string]string{"memory-gt": "8G", "memory-lt": "512M"},
+			want:  []string{"memorySize>8589934592", "memorySize<536870912"},
+		},
+		{
+			name:    "invalid size",
+			flags:   map[string]string{"memory-gt": "lots"},
+			wantErr: "invalid --memory-gt",
+		},
+		{
+			name:  "pattern with wildcard",
+			flags: map[string]string{"ip": "10.0.0.*"},
+			want:  []string{"vmNics.ip~=10.0.0.%"},
+		},
+		{
+			name:  "pattern without wildcard",
+			flags: map[string]string{"ip": "10.0.0.5"},
+			want:  []string{"vmNics.ip=10.0.0.5"},
+		},
+		{
+			name:  "date bounds",
+			flags: map[string]string{"created-after": "2025-01-02", "created-before": "2025-03-04 05:06:07"},
+			want: []string{
+				"createDate>" + localQueryTime(t, "2006-01-02", "2025-01-02"),
+				"createDate<" + localQueryTime(t, "2006-01-02 15:04:05", "2025-03-04 05:06:07"),
+			},
+		},
+		{
+			name:  "RFC 3339 date",
+			flags: map[string]string{"created-after": "2025-01-01T08:00:00Z"},
+			want:  []string{"createDate>" + time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC).Local().Format(queryTimeFormat)},
+		},
+		{
+			name:    "invalid date",
+			flags:   map[string]string{"created-before": "yesterday"},
+			wantErr: "invalid --created-before: unrecognized date 'yesterday'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compileFilters(t, tt.flags)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("conditions = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
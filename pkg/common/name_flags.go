@@ -0,0 +1,35 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+)
+
+func AddResolveNamesFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("resolve-names", false, "Show names of related resources instead of their UUIDs (UUIDs are kept with -o wide, json or yaml)")
+}
+
+// NameResolverFromFlags returns a resolver if --resolve-names is set and nil
+// otherwise. The nil resolver is safe to use and resolves nothing.
+func NameResolverFromFlags(cmd *cobra.Command, zsClient *sdkClient.ZSClient) *client.NameResolver {
+	resolveNames, _ := cmd.Flags().GetBool("resolve-names")
+	if !resolveNames {
+		return nil
+	}
+	return client.NewNameResolver(zsClient)
+}
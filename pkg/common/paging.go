@@ -0,0 +1,120 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+// DefaultPageSize is the number of results fetched per request with --all.
+const DefaultPageSize = 500
+
+// AddPagingFlags adds --all and --page-size to a get command. Only the
+// commands whose inventories grow large (instances, disks, images,
+// snapshots and nics) stream their results with StreamAllPages.
+func AddPagingFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("all", false, "Fetch all results page by page, printing each page as it arrives")
+	cmd.Flags().Int("page-size", DefaultPageSize, "Number of results fetched per request with --all")
+}
+
+// AllPages reports whether --all is set on cmd.
+func AllPages(cmd *cobra.Command) bool {
+	all, _ := cmd.Flags().GetBool("all")
+	return all
+}
+
+// QueryPages calls query repeatedly with increasing start offsets and passes
+// each page to handle. Paging begins at --start and stops after a short page
+// or once --limit results have been handled. Results are sorted by uuid
+// unless --sort is given, so that offsets stay stable between requests.
+func QueryPages[T any](cmd *cobra.Command, queryParam *param.QueryParam, query func(param.QueryParam) ([]T, error), handle func([]T) error) error {
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+	if pageSize <= 0 {
+		return fmt.Errorf("page size must be positive, got %d", pageSize)
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	start, _ := cmd.Flags().GetInt("start")
+
+	base := cloneQueryParam(queryParam)
+	if base.Get("sort") == "" {
+		base.Sort("+uuid")
+	}
+
+	fetched := 0
+	for {
+		size := pageSize
+		if limit > 0 && limit-fetched < size {
+			size = limit - fetched
+		}
+		if size <= 0 {
+			return nil
+		}
+
+		page := cloneQueryParam(&base)
+		page.Start(start + fetched)
+		page.Limit(size)
+
+		items, err := query(page)
+		if err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			if err := handle(items); err != nil {
+				return err
+			}
+		}
+
+		fetched += len(items)
+		if len(items) < size {
+			return nil
+		}
+	}
+}
+
+// cloneQueryParam deep-copies queryParam. The SDK records reply metadata in
+// the values it is given, so each request gets its own copy.
+func cloneQueryParam(queryParam *param.QueryParam) param.QueryParam {
+	clone := param.QueryParam{Values: url.Values{}}
+	for k, v := range queryParam.Values {
+		clone.Values[k] = append([]string(nil), v...)
+	}
+	return clone
+}
+
+// StreamAllPages pages through every result with QueryPages and prints each
+// page as soon as it arrives, using the --output and --fields flags of cmd.
+// format converts a page of inventories into printable rows.
+func StreamAllPages[T any](cmd *cobra.Command, queryParam *param.QueryParam, query func(param.QueryParam) ([]T, error), format func([]T) (interface{}, error)) error {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+
+	printer := utils.NewStreamPrinter(utils.ParseFormat(outputFormat), fields)
+	err := QueryPages(cmd, queryParam, query, func(items []T) error {
+		rows, err := format(items)
+		if err != nil {
+			return err
+		}
+		return printer.Print(rows)
+	})
+	if err != nil {
+		return err
+	}
+	return printer.Close()
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+// pageRequest is the paging part of one query sent by QueryPages.
+type pageRequest struct {
+	start, limit int
+	sort         string
+}
+
+func pagingCommand(t *testing.T, flags map[string]string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	AddPagingFlags(cmd)
+	cmd.Flags().Int("limit", 0, "")
+	cmd.Flags().Int("start", 0, "")
+	for name, value := range flags {
+		if err := cmd.Flags().Set(name, value); err != nil {
+			t.Fatalf("set --%s: %v", name, err)
+		}
+	}
+	return cmd
+}
+
+// fakeQuery serves the items 0..total-1 and records each request.
+func fakeQuery(total int, requests *[]pageRequest) func(param.QueryParam) ([]int, error) {
+	return func(p param.QueryParam) ([]int, error) {
+		start, _ := strconv.Atoi(p.Get("start"))
+		limit, _ := strconv.Atoi(p.Get("limit"))
+		*requests = append(*requests, pageRequest{start: start, limit: limit, sort: p.Get("sort")})
+
+		var items []int
+		for i := start; i < total && i < start+limit; i++ {
+			items = append(items, i)
+		}
+		return items, nil
+	}
+}
+
+func TestQueryPages(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        int
+		flags        map[string]string
+		sort         string
+		wantRequests []pageRequest
+		wantPages    [][]int
+	}{
+		{
+			name:         "short last page",
+			total:        7,
+			flags:        map[string]string{"page-size": "3"},
+			wantRequests: []pageRequest{{0, 3, "+uuid"}, {3, 3, "+uuid"}, {6, 3, "+uuid"}},
+			wantPages:    [][]int{{0, 1, 2}, {3, 4, 5}, {6}},
+		},
+		{
+			name:         "empty last page is not handled",
+			total:        6,
+			flags:        map[string]string{"page-size": "3"},
+			wantRequests: []pageRequest{{0, 3, "+uuid"}, {3, 3, "+uuid"}, {6, 3, "+uuid"}},
+			wantPages:    [][]int{{0, 1, 2}, {3, 4, 5}},
+		},
+		{
+			name:         "limit",
+			total:        10,
+			flags:        map[string]string{"page-size": "3", "limit": "5"},
+			wantRequests: []pageRequest{{0, 3, "+uuid"}, {3, 2, "+uuid"}},
+			wantPages:    [][]int{{0, 1, 2}, {3, 4}},
+		},
+		{
+			name:         "start and limit",
+			total:        10,
+			flags:        map[string]string{"page-size": "3", "start": "2", "limit": "4"},
+			wantRequests: []pageRequest{{2, 3, "+uuid"}, {5, 1, "+uuid"}},
+			wantPages:    [][]int{{2, 3, 4}, {5}},
+		},
+		{
+			name:         "explicit sort",
+			total:        2,
+			flags:        map[string]string{"page-size": "5"},
+			sort:         "-createDate",
+			wantRequests: []pageRequest{{0, 5, "-createDate"}},
+			wantPages:    [][]int{{0, 1}},
+		},
+		{
+			name:         "no results",
+			total:        0,
+			wantRequests: []pageRequest{{0, DefaultPageSize, "+uuid"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := pagingCommand(t, tt.flags)
+			queryParam := param.NewQueryParam()
+			queryParam.AddQ("state=Running")
+			if tt.sort != "" {
+				queryParam.Sort(tt.sort)
+			}
+			before := cloneQueryParam(&queryParam)
+
+			var requests []pageRequest
+			var pages [][]int
+			err := QueryPages(cmd, &queryParam, fakeQuery(tt.total, &requests), func(items []int) error {
+				pages = append(pages, items)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("requests = %+v, want %+v", requests, tt.wantRequests)
+			}
+			if !reflect.DeepEqual(pages, tt.wantPages) {
+				t.Errorf("pages = %v, want %v", pages, tt.wantPages)
+			}
+			if !reflect.DeepEqual(queryParam.Values, before.Values) {
+				t.Errorf("query parameters were modified: %v, want %v", queryParam.Values, before.Values)
+			}
+		})
+	}
+}
+
+func TestQueryPagesErrors(t *testing.T) {
+	errQuery := errors.New("query failed")
+	errHandle := errors.New("handle failed")
+	ok := func([]int) error { return nil }
+
+	tests := []struct {
+		name    string
+		query   func(param.QueryParam) ([]int, error)
+		handle  func([]int) error
+		wantErr error
+	}{
+		{
+			name:    "query error",
+			query:   func(param.QueryParam) ([]int, error) { return nil, errQuery },
+			handle:  ok,
+			wantErr: errQuery,
+		},
+		{
+			name:    "handle error",
+			query:   fakeQuery(3, new([]pageRequest)),
+			handle:  func([]int) error { return errHandle },
+			wantErr: errHandle,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryParam := param.NewQueryParam()
+			err := QueryPages(pagingCommand(t, nil), &queryParam, tt.query, tt.handle)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("page size", func(t *testing.T) {
+		queryParam := param.NewQueryParam()
+		err := QueryPages(pagingCommand(t, map[string]string{"page-size": "0"}), &queryParam, fakeQuery(3, new([]pageRequest)), ok)
+		if err == nil {
+			t.Error("expected an error for --page-size 0")
+		}
+	})
+}
@@ -26,11 +26,11 @@ func AddQueryFlags(cmd *cobra.Command) {
 	cmd.Flags().StringArrayP("q", "q", []string{}, "Query condition, can be specified multiple times")
 	cmd.Flags().IntP("limit", "l", 0, "Maximum number of results to return")
 	cmd.Flags().IntP("start", "s", 0, "Starting index for results")
-	cmd.Flags().Bool("count", false, "Return count of matching records only")
-	cmd.Flags().Bool("reply-with-count", false, "Include total count in response")
+	cmd.Flags().Bool("count", false, "Return only the number of matching records")
+	cmd.Flags().Bool("reply-with-count", false, "Print the total number of matching records along with the results")
 	cmd.Flags().String("sort", "", "Sort results by field (e.g. '+name' or '-createDate')")
-	cmd.Flags().String("group-by", "", "Group results by field")
-	cmd.Flags().StringP("output", "o", "table", "Output format: table, json, yaml, or text")
+	cmd.Flags().String("group-by", "", "Count matching records per value of a field (e.g. state or hostUuid)")
+	cmd.Flags().StringP("output", "o", "table", "Output format: table, wide, json, jsonl, yaml, or text")
 	cmd.Flags().StringSlice("fields", nil, "Fields to display (use comma without spaces: --fields name,uuid,type or multiple flags: --fields name --fields type)")
 }
 
@@ -42,6 +42,10 @@ func BuildQueryParams(cmd *cobra.Command, args []string, nameField string) (*par
 		queryParam.AddQ(fmt.Sprintf("%s=%s", nameField, resourceName))
 	}
 
+	if err := addFilterConditions(cmd, &queryParam); err != nil {
+		return nil, err
+	}
+
 	qConditions, _ := cmd.Flags().GetStringArray("q")
 	for _, q := range qConditions {
 		queryParam.AddQ(q)
@@ -0,0 +1,257 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/client"
+	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+// Values of --match, which chooses how VM names given as arguments are matched.
+const (
+	MatchExact     = "exact"
+	MatchSubstring = "substring"
+)
+
+// AddMatchFlag adds --match to a command that takes VM names as arguments,
+// along with --exact, which asks for the default explicitly.
+func AddMatchFlag(cmd *cobra.Command) {
+	cmd.Flags().String("match", MatchExact, "How VM names are matched: exact or substring")
+	cmd.Flags().Bool("exact", false, "Match VM names exactly (the default, same as --match exact)")
+}
+
+// AddVMSelectorFlags adds the flags that choose the VMs a batch command acts
+// on, in addition to the names or UUIDs given as arguments.
+func AddVMSelectorFlags(cmd *cobra.Command) {
+	cmd.Flags().String("selector", "", "Tag selector, e.g. env=prod,!canary (key=value matches the user tag key::value)")
+	cmd.Flags().StringArrayP("q", "q", []string{}, "Query condition, can be specified multiple times")
+	cmd.Flags().String("host", "", "Only VMs on this host (name or UUID)")
+	cmd.Flags().String("cluster", "", "Only VMs in this cluster (name or UUID)")
+	cmd.Flags().String("from-file", "", "Read VM names or UUIDs from a file, one per line")
+	AddMatchFlag(cmd)
+}
+
+// SelectVMs returns the VMs chosen by the names or UUIDs in args and
+// --from-file, narrowed by --selector, -q, --host and --cluster. Only VMs
+// whose state passes keep are returned. Without any names the narrowing
+// flags alone select the VMs, but at least one of them must be given. A
+// name or UUID that matches no kept VM is an error, so a typo never leaves
+// the rest of the batch to run unnoticed. Names are matched exactly unless
+// --match substring is given.
+func SelectVMs(cmd *cobra.Command, cli *sdkClient.ZSClient, args []string, keep func(state string) bool) ([]view.VmInstanceInventoryView, error) {
+	identifiers := append([]string{}, args...)
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	if fromFile != "" {
+		lines, err := readIdentifiers(fromFile)
+		if err != nil {
+			return nil, err
+		}
+		identifiers = append(identifiers, lines...)
+	}
+
+	conditions, err := vmSelectorConditions(cmd, cli)
+	if err != nil {
+		return nil, err
+	}
+	if len(identifiers) == 0 && len(conditions) == 0 {
+		return nil, fmt.Errorf("specify VM names or UUIDs, --from-file, --selector, -q, --host or --cluster")
+	}
+
+	seen := map[string]bool{}
+	var selected []view.VmInstanceInventoryView
+	add := func(vms []view.VmInstanceInventoryView) int {
+		n := 0
+		for _, vm := range vms {
+			if !keep(vm.State) {
+				continue
+			}
+			n++
+			if !seen[vm.UUID] {
+				seen[vm.UUID] = true
+				selected = append(selected, vm)
+			}
+		}
+		return n
+	}
+
+	if len(identifiers) == 0 {
+		vms, err := queryVMs(cli, conditions)
+		if err != nil {
+			return nil, err
+		}
+		add(vms)
+		return selected, nil
+	}
+
+	var unmatched []string
+	for _, id := range identifiers {
+		vms, err := MatchVMs(cmd, cli, id, conditions)
+		if err != nil {
+			return nil, err
+		}
+		if add(vms) == 0 {
+			unmatched = append(unmatched, id)
+		}
+	}
+	if len(unmatched) > 0 {
+		return nil, fmt.Errorf("no matching VMs found for: %s", strings.Join(unmatched, ", "))
+	}
+	return selected, nil
+}
+
+// MatchVMs returns the VMs whose UUID is id or, failing that, whose name
+// matches id according to --match, narrowed by conditions.
+func MatchVMs(cmd *cobra.Command, cli *sdkClient.ZSClient, id string, conditions []string) ([]view.VmInstanceInventoryView, error) {
+	nameCondition, err := NameCondition(cmd, id)
+	if err != nil {
+		return nil, err
+	}
+	vms, err := queryVMs(cli, append(conditions, fmt.Sprintf("uuid=%s", id)))
+	if err != nil || len(vms) > 0 {
+		return vms, err
+	}
+	return queryVMs(cli, append(conditions, nameCondition))
+}
+
+// NameCondition returns the query condition that matches name according to
+// --match.
+func NameCondition(cmd *cobra.Command, name string) (string, error) {
+	match, _ := cmd.Flags().GetString("match")
+	if exact, _ := cmd.Flags().GetBool("exact"); exact && match != MatchExact && match != "" {
+		return "", fmt.Errorf("--exact cannot be used with --match %s", match)
+	}
+	switch match {
+	case MatchExact, "":
+		return fmt.Sprintf("name=%s", name), nil
+	case MatchSubstring:
+		return fmt.Sprintf("name~=%%%s%%", name), nil
+	default:
+		return "", fmt.Errorf("invalid --match '%s', must be %s or %s", match, MatchExact, MatchSubstring)
+	}
+}
+
+// vmSelectorConditions converts --selector, -q, --host and --cluster into
+// query conditions.
+func vmSelectorConditions(cmd *cobra.Command, cli *sdkClient.ZSClient) ([]string, error) {
+	selector, _ := cmd.Flags().GetString("selector")
+	conditions, err := ParseTagSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	qConditions, _ := cmd.Flags().GetStringArray("q")
+	conditions = append(conditions, qConditions...)
+
+	host, _ := cmd.Flags().GetString("host")
+	if host != "" {
+		hostUUID, err := client.GetHostUUIDByName(cli, host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find host '%s': %v", host, err)
+		}
+		conditions = append(conditions, fmt.Sprintf("hostUuid=%s", hostUUID))
+	}
+
+	cluster, _ := cmd.Flags().GetString("cluster")
+	if cluster != "" {
+		clusterUUID, err := client.GetClusterUUIDByName(cli, cluster)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find cluster '%s': %v", cluster, err)
+		}
+		conditions = append(conditions, fmt.Sprintf("clusterUuid=%s", clusterUUID))
+	}
+
+	return conditions, nil
+}
+
+// ParseTagSelector converts a comma separated tag selector into user tag
+// query conditions. Each term must hold for a resource to match:
+//
+//	key=value   has the user tag key::value
+//	key!=value  does not have the user tag key::value
+//	key         has the user tag key
+//	!key        does not have the user tag key
+func ParseTagSelector(selector string) ([]string, error) {
+	var conditions []string
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		op, tag := "=", term
+		switch {
+		case strings.Contains(term, "!="):
+			key, value, _ := strings.Cut(term, "!=")
+			op, tag = "!=", userTag(key, value)
+		case strings.Contains(term, "="):
+			key, value, _ := strings.Cut(strings.Replace(term, "==", "=", 1), "=")
+			tag = userTag(key, value)
+		case strings.HasPrefix(term, "!"):
+			op, tag = "!=", strings.TrimSpace(term[1:])
+		}
+		if tag == "" || strings.HasPrefix(tag, "::") || strings.HasSuffix(tag, "::") || strings.ContainsAny(tag, "!= ") {
+			return nil, fmt.Errorf("invalid selector term '%s'", term)
+		}
+		conditions = append(conditions, fmt.Sprintf("__userTag__%s%s", op, tag))
+	}
+	return conditions, nil
+}
+
+func userTag(key, value string) string {
+	return strings.TrimSpace(key) + "::" + strings.TrimSpace(value)
+}
+
+func queryVMs(cli *sdkClient.ZSClient, conditions []string) ([]view.VmInstanceInventoryView, error) {
+	queryParam := param.NewQueryParam()
+	for _, c := range conditions {
+		queryParam.AddQ(c)
+	}
+	return cli.QueryVmInstance(queryParam)
+}
+
+// readIdentifiers reads one VM name or UUID per line from path, ignoring
+// blank lines and lines starting with #.
+func readIdentifiers(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	defer f.Close()
+
+	var identifiers []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		identifiers = append(identifiers, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	if len(identifiers) == 0 {
+		return nil, fmt.Errorf("%s does not list any VMs", path)
+	}
+	return identifiers, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseTagSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     []string
+		wantErr  bool
+	}{
+		{selector: "", want: nil},
+		{selector: "env=prod", want: []string{"__userTag__=env::prod"}},
+		{selector: "env==prod", want: []string{"__userTag__=env::prod"}},
+		{selector: "env!=prod", want: []string{"__userTag__!=env::prod"}},
+		{selector: "pinned", want: []string{"__userTag__=pinned"}},
+		{selector: "!canary", want: []string{"__userTag__!=canary"}},
+		{
+			selector: " env = staging , !pinned ,, team=db ",
+			want:     []string{"__userTag__=env::staging", "__userTag__!=pinned", "__userTag__=team::db"},
+		},
+		{selector: "=prod", wantErr: true},
+		{selector: "env=", wantErr: true},
+		{selector: "!", wantErr: true},
+		{selector: "env=a=b", wantErr: true},
+		{selector: "two words", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := ParseTagSelector(tt.selector)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTagSelector(%q) = %q, want an error", tt.selector, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTagSelector(%q) = %q, want %q", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameCondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", want: "name=web-1"},
+		{name: "exact", flags: map[string]string{"match": MatchExact}, want: "name=web-1"},
+		{name: "substring", flags: map[string]string{"match": MatchSubstring}, want: "name~=%web-1%"},
+		{name: "--exact", flags: map[string]string{"exact": "true"}, want: "name=web-1"},
+		{name: "--exact with --match exact", flags: map[string]string{"exact": "true", "match": MatchExact}, want: "name=web-1"},
+		{name: "--exact with --match substring", flags: map[string]string{"exact": "true", "match": MatchSubstring}, wantErr: true},
+		{name: "unknown mode", flags: map[string]string{"match": "fuzzy"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "test"}
+			AddMatchFlag(cmd)
+			for name, value := range tt.flags {
+				if err := cmd.Flags().Set(name, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := NameCondition(cmd, "web-1")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NameCondition = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("NameCondition = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+	sdkClient "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/client"
+)
+
+// ExitCodeWaitTimeout is the exit status when --wait gives up, so scripts
+// can tell a timeout from a failed operation (exit status 1). Execute exits
+// with it when the error of a command is a *WaitTimeoutError.
+const ExitCodeWaitTimeout = 3
+
+const (
+	DefaultWaitTimeout = 10 * time.Minute
+	waitPollInterval   = 2 * time.Second
+	// waitReportInterval is how often an unchanged state is reported again.
+	waitReportInterval = 30 * time.Second
+)
+
+// WaitTimeoutError is returned by Waiter.Until when the deadline passes.
+type WaitTimeoutError struct {
+	Name  string
+	State string
+}
+
+func (e *WaitTimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for %s, last state %s", e.Name, e.State)
+}
+
+func (e *WaitTimeoutError) Timeout() bool { return true }
+
+// Poller returns the current state or status of a resource.
+type Poller func() (string, error)
+
+func AddWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("wait", false, "Wait until the operation has finished")
+	cmd.Flags().Duration("timeout", DefaultWaitTimeout, "Maximum time to wait with --wait (e.g. 90s, 10m)")
+}
+
+// Waiter polls resources until they reach a target state. All waits of one
+// command share a single deadline.
+type Waiter struct {
+	Enabled  bool
+	deadline time.Time
+	w        io.Writer
+	timedOut *atomic.Pointer[WaitTimeoutError]
+}
+
+// NewWaiter reads --wait and --timeout from cmd. Progress goes to w.
+func NewWaiter(cmd *cobra.Command, w io.Writer) *Waiter {
+	wait, _ := cmd.Flags().GetBool("wait")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+	return &Waiter{Enabled: wait, deadline: time.Now().Add(timeout), w: w, timedOut: new(atomic.Pointer[WaitTimeoutError])}
+}
+
+// WithOutput returns a copy of wt that reports progress to w, so that
+// concurrent waits can keep their output apart. Timeouts are still reported
+// by wt.Finish.
+func (wt *Waiter) WithOutput(w io.Writer) *Waiter {
+	c := *wt
+	c.w = w
+	return &c
+}
+
+// Until polls until the state is one of targets. Reaching one of failures,
+// or the deadline, ends the wait with an error.
+func (wt *Waiter) Until(name string, poll Poller, targets, failures []string) error {
+	return wt.UntilDone(name, func() (bool, string, error) {
+		state, err := poll()
+		if err != nil {
+			return false, "", err
+		}
+		if containsString(failures, state) {
+			return false, state, fmt.Errorf("%s ended in state %s", name, state)
+		}
+		return containsString(targets, state), state, nil
+	})
+}
+
+// Check reports whether a wait is over along with a description of the
+// current state. An error ends the wait.
+type Check func() (done bool, state string, err error)
+
+// UntilDone polls check until it reports done, fails or the deadline passes.
+// The state is printed whenever it changes.
+func (wt *Waiter) UntilDone(name string, check Check) error {
+	start := time.Now()
+	lastState := ""
+	lastReport := time.Time{}
+
+	for {
+		done, state, err := check()
+		if state != "" && (state != lastState || time.Since(lastReport) >= waitReportInterval) {
+			fmt.Fprintf(wt.w, "  %s: %s (%s)\n", name, state, time.Since(start).Round(time.Second))
+			lastState = state
+			lastReport = time.Now()
+		}
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		if time.Now().Add(waitPollInterval).After(wt.deadline) {
+			err := &WaitTimeoutError{Name: name, State: lastState}
+			wt.timedOut.CompareAndSwap(nil, err)
+			return err
+		}
+		time.Sleep(waitPollInterval)
+	}
+}
+
+// Finish returns the first *WaitTimeoutError of the waits, or nil if none
+// timed out. Batch commands, which record a timeout as the failure of one
+// item, return it after printing their results so that they exit with
+// ExitCodeWaitTimeout.
+func (wt *Waiter) Finish() error {
+	if err := wt.timedOut.Load(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// PollVMState polls the state of a VM instance.
+func PollVMState(cli *sdkClient.ZSClient, uuid string) Poller {
+	return func() (string, error) {
+		vm, err := cli.GetVmInstance(uuid)
+		if err != nil {
+			return "", err
+		}
+		return vm.State, nil
+	}
+}
+
+// PollImageStatus polls the status of an image.
+func PollImageStatus(cli *sdkClient.ZSClient, uuid string) Poller {
+	return func() (string, error) {
+		img, err := cli.GetImage(uuid)
+		if err != nil {
+			return "", err
+		}
+		return img.Status, nil
+	}
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// waitCommand is a command with the wait flags set to args.
+func waitCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	AddWaitFlags(cmd)
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+// states is a Poller that returns each of values in turn and then repeats
+// the last one.
+func states(values ...string) Poller {
+	i := 0
+	return func() (string, error) {
+		v := values[i]
+		if i < len(values)-1 {
+			i++
+		}
+		return v, nil
+	}
+}
+
+func TestWaiterUntil(t *testing.T) {
+	pollErr := errors.New("connection refused")
+
+	// A timeout shorter than the poll interval makes any wait that is not
+	// over after the first poll time out without sleeping.
+	tests := []struct {
+		name     string
+		poll     Poller
+		wantErr  string
+		timedOut bool
+		output   string
+	}{
+		{
+			name:   "target reached",
+			poll:   states("Running"),
+			output: "  vm: Running",
+		},
+		{
+			name:    "failure state",
+			poll:    states("Destroyed"),
+			wantErr: "vm ended in state Destroyed",
+			output:  "  vm: Destroyed",
+		},
+		{
+			name:    "poll error",
+			poll:    func() (string, error) { return "", pollErr },
+			wantErr: "connection refused",
+		},
+		{
+			name:     "timeout",
+			poll:     states("Stopping"),
+			wantErr:  "timed out waiting for vm, last state Stopping",
+			timedOut: true,
+			output:   "  vm: Stopping",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			wt := NewWaiter(waitCommand(t, "--wait", "--timeout", "1ms"), &out)
+			if !wt.Enabled {
+				t.Fatal("--wait was not read")
+			}
+
+			err := wt.Until("vm", tt.poll, []string{"Running"}, []string{"Destroyed"})
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+
+			var timeoutErr *WaitTimeoutError
+			if got := errors.As(err, &timeoutErr); got != tt.timedOut {
+				t.Errorf("WaitTimeoutError = %v, want %v", got, tt.timedOut)
+			}
+			if finished := wt.Finish(); (finished != nil) != tt.timedOut {
+				t.Errorf("Finish() = %v, want a timeout: %v", finished, tt.timedOut)
+			}
+			if !strings.HasPrefix(out.String(), tt.output) {
+				t.Errorf("output = %q, want prefix %q", out.String(), tt.output)
+			}
+		})
+	}
+}
+
+func TestWaiterDefaults(t *testing.T) {
+	wt := NewWaiter(waitCommand(t, "--timeout", "0s"), &bytes.Buffer{})
+	if wt.Enabled {
+		t.Error("Enabled without --wait")
+	}
+	if left := time.Until(wt.deadline); left < DefaultWaitTimeout-time.Minute {
+		t.Errorf("deadline in %s, want about %s", left, DefaultWaitTimeout)
+	}
+}
+
+func TestWaiterWithOutputSharesTimeout(t *testing.T) {
+	var out, itemOut bytes.Buffer
+	wt := NewWaiter(waitCommand(t, "--wait", "--timeout", "1ms"), &out)
+	item := wt.WithOutput(&itemOut)
+
+	if err := item.Until("vm", states("Stopping"), []string{"Running"}, nil); err == nil {
+		t.Fatal("expected a timeout")
+	}
+	if out.Len() != 0 || itemOut.Len() == 0 {
+		t.Errorf("progress went to the wrong writer: waiter %q, item %q", out.String(), itemOut.String())
+	}
+	if wt.Finish() == nil {
+		t.Error("timeout of a WithOutput copy is not seen by the original")
+	}
+}
+
+func TestWaiterFinish(t *testing.T) {
+	wt := NewWaiter(waitCommand(t, "--wait", "--timeout", "1ms"), &bytes.Buffer{})
+	if err := wt.Finish(); err != nil {
+		t.Fatalf("Finish() = %v before any wait", err)
+	}
+
+	_ = wt.Until("vm-1", states("Stopping"), []string{"Running"}, nil)
+	_ = wt.Until("vm-2", states("Starting"), []string{"Running"}, nil)
+
+	// A batch reports the timeout after its summary, wrapped or not.
+	err := fmt.Errorf("failed to stop: %w", wt.Finish())
+	var timeout *WaitTimeoutError
+	if !errors.As(err, &timeout) {
+		t.Fatalf("Finish() = %v, want a *WaitTimeoutError", err)
+	}
+	if timeout.Name != "vm-1" || timeout.State != "Stopping" {
+		t.Errorf("Finish() = %+v, want the first timeout", timeout)
+	}
+}
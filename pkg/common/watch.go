@@ -0,0 +1,210 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chijiajian/zstack-cli-go/pkg/utils"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+const DefaultWatchInterval = 2 * time.Second
+
+// watchIncompatibleFlags print something other than one result set per
+// query, so there is nothing to compare between queries.
+var watchIncompatibleFlags = []string{"all", "count", "group-by", "pagination", "reply-with-count"}
+
+// AddWatchFlags adds --watch and --interval to cmd and its subcommands.
+func AddWatchFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolP("watch", "w", false, "Keep querying and print resources that were added, removed or changed")
+	cmd.PersistentFlags().Duration("interval", DefaultWatchInterval, "Time between queries with --watch (e.g. 5s, 1m)")
+}
+
+// WatchEvent is printed for each changed resource in JSON and YAML watch
+// output.
+type WatchEvent struct {
+	Time    time.Time   `json:"time" yaml:"time"`
+	Type    string      `json:"type" yaml:"type"`
+	Object  interface{} `json:"object" yaml:"object"`
+	Changes []string    `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+const (
+	WatchAdded   = "ADDED"
+	WatchRemoved = "REMOVED"
+	WatchChanged = "CHANGED"
+)
+
+// ResultPrinter prints the result set of a get command.
+type ResultPrinter func(data interface{}, format utils.OutputFormat, fields []string) error
+
+type resultPrinterKey struct{}
+
+// PrintResult prints the result set of a get command. A command wrapped in
+// WatchRun must print its result through PrintResult, so that --watch
+// receives each result instead of it being printed.
+func PrintResult(cmd *cobra.Command, data interface{}, format utils.OutputFormat, fields []string) error {
+	if ctx := cmd.Context(); ctx != nil {
+		if p, ok := ctx.Value(resultPrinterKey{}).(ResultPrinter); ok {
+			return p(data, format, fields)
+		}
+	}
+	return utils.PrintWithFields(data, format, fields)
+}
+
+// WatchRun wraps the Run function of a get command. With --watch, run is
+// repeated every --interval until the command is interrupted. On a terminal
+// the table is redrawn in place; otherwise the first result is printed in
+// full and later queries print one timestamped line per changed resource.
+// run must print its result with PrintResult.
+func WatchRun(run func(*cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if watch, _ := cmd.Flags().GetBool("watch"); !watch {
+			run(cmd, args)
+			return
+		}
+		if err := watchCommand(cmd, args, run); err != nil {
+			fmt.Printf("Error: %s\n", err)
+		}
+	}
+}
+
+func watchCommand(cmd *cobra.Command, args []string, run func(*cobra.Command, []string)) error {
+	for _, name := range watchIncompatibleFlags {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			return fmt.Errorf("--watch cannot be combined with --%s", name)
+		}
+	}
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", interval)
+	}
+
+	outputFormat, _ := cmd.Flags().GetString("output")
+	format := utils.ParseFormat(outputFormat)
+	fields, _ := cmd.Flags().GetStringSlice("fields")
+	redraw := !utils.IsStructuredFormat(format) && term.IsTerminal(int(os.Stdout.Fd()))
+
+	// query runs the command once and returns the result it printed. ok is
+	// false if it printed nothing, which usually means it failed and has
+	// reported the error itself.
+	query := func() (data interface{}, ok bool) {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		defer cmd.SetContext(ctx)
+		cmd.SetContext(context.WithValue(ctx, resultPrinterKey{}, ResultPrinter(func(d interface{}, _ utils.OutputFormat, _ []string) error {
+			data, ok = d, true
+			return nil
+		})))
+		run(cmd, args)
+		return data, ok
+	}
+
+	var prev map[string]utils.WatchRow
+	for first := true; ; first = false {
+		if !first {
+			time.Sleep(interval)
+		}
+
+		data, ok := query()
+		if !ok {
+			// The command has reported why it failed. Give up if it never
+			// worked, otherwise assume the error is transient.
+			if first {
+				return nil
+			}
+			continue
+		}
+
+		if redraw {
+			fmt.Print("\033[H\033[2J")
+			fmt.Printf("Every %s: %s\t%s\n\n", interval, strings.Join(os.Args[1:], " "), time.Now().Format(time.DateTime))
+			if err := utils.PrintWithFields(data, format, fields); err != nil {
+				return err
+			}
+			continue
+		}
+
+		columns, rows := utils.WatchRows(data, fields, format == utils.WideFormat)
+		current := make(map[string]utils.WatchRow, len(rows))
+		for _, row := range rows {
+			current[row.Key] = row
+		}
+
+		if first && !utils.IsStructuredFormat(format) {
+			if err := utils.PrintWithFields(data, format, fields); err != nil {
+				return err
+			}
+		} else {
+			printWatchEvents(format, columns, rows, current, prev)
+		}
+		prev = current
+	}
+}
+
+// printWatchEvents prints resources that were added or changed since prev,
+// in the order of rows, followed by those that were removed.
+func printWatchEvents(format utils.OutputFormat, columns []string, rows []utils.WatchRow, current, prev map[string]utils.WatchRow) {
+	now := time.Now()
+	for _, row := range rows {
+		old, existed := prev[row.Key]
+		if !existed {
+			printWatchEvent(format, now, WatchAdded, row, nil)
+		} else if changes := row.Changes(old, columns); len(changes) > 0 {
+			printWatchEvent(format, now, WatchChanged, row, changes)
+		}
+	}
+
+	var removed []string
+	for key := range prev {
+		if _, ok := current[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	for _, key := range removed {
+		printWatchEvent(format, now, WatchRemoved, prev[key], nil)
+	}
+}
+
+func printWatchEvent(format utils.OutputFormat, now time.Time, eventType string, row utils.WatchRow, changes []string) {
+	event := WatchEvent{Time: now, Type: eventType, Object: row.Object, Changes: changes}
+	switch format {
+	case utils.JSONFormat, utils.JSONLinesFormat:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetEscapeHTML(false)
+		enc.Encode(event)
+	case utils.YAMLFormat:
+		data, _ := yaml.Marshal(event)
+		fmt.Printf("---\n%s", data)
+	default:
+		line := fmt.Sprintf("%s  %-7s  %s", now.Format(time.DateTime), eventType, row.Label())
+		if len(changes) > 0 {
+			line += "  " + strings.Join(changes, ", ")
+		}
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package console
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+// dialTimeout bounds the websocket handshake with the console proxy.
+const dialTimeout = 15 * time.Second
+
+// WebsocketURL returns the console proxy websocket URL for a console
+// session. The proxy reports its scheme as the console protocol (vnc,
+// spice), so only an explicit TLS scheme selects wss.
+func WebsocketURL(inv view.ConsoleInventoryView) string {
+	scheme := "ws"
+	if inv.Scheme == "wss" || inv.Scheme == "https" {
+		scheme = "wss"
+	}
+	u := url.URL{
+		Scheme:   scheme,
+		Host:     net.JoinHostPort(inv.Hostname, fmt.Sprint(inv.Port)),
+		Path:     "/websockify",
+		RawQuery: url.Values{"token": {inv.Token}}.Encode(),
+	}
+	return u.String()
+}
+
+// Proxy accepts local TCP connections, such as a VNC viewer, and bridges
+// each one to a new console session on the console proxy.
+type Proxy struct {
+	// Session returns the websocket URL of a fresh console session; tokens
+	// are single use, so it is called once per accepted connection.
+	Session func() (string, error)
+	// Insecure skips verification of the proxy's TLS certificate.
+	Insecure bool
+	// Logf reports connection events.
+	Logf func(format string, args ...interface{})
+}
+
+// Serve handles connections on l until it is closed.
+func (p *Proxy) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go p.handle(conn)
+	}
+}
+
+func (p *Proxy) handle(local net.Conn) {
+	defer local.Close()
+	p.Logf("Connection from %s\n", local.RemoteAddr())
+
+	wsURL, err := p.Session()
+	if err != nil {
+		p.Logf("Error requesting console access: %v\n", err)
+		return
+	}
+
+	remote, err := dialWebsocket(wsURL, p.Insecure, dialTimeout)
+	if err != nil {
+		p.Logf("Error connecting to console proxy: %v\n", err)
+		return
+	}
+	defer remote.Close()
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(remote, local)
+		done <- err
+	}()
+	go func() {
+		_, err := io.Copy(local, remote)
+		done <- err
+	}()
+
+	if err := <-done; err != nil && !isClosedError(err) {
+		p.Logf("Connection from %s ended: %v\n", local.RemoteAddr(), err)
+		return
+	}
+	p.Logf("Connection from %s closed\n", local.RemoteAddr())
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package console
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serverFrame encodes an unmasked frame as a server sends it.
+func serverFrame(opcode byte, payload []byte) []byte {
+	frame := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, byte(n))
+	case n <= 0xffff:
+		frame = append(frame, 126, byte(n>>8), byte(n))
+	default:
+		var ext [8]byte
+		binary.BigEndian.PutUint64(ext[:], uint64(n))
+		frame = append(frame, 127)
+		frame = append(frame, ext[:]...)
+	}
+	return append(frame, payload...)
+}
+
+// pipeConn returns a client connection and the server end of its pipe.
+func pipeConn(t *testing.T) (*wsConn, net.Conn) {
+	t.Helper()
+	client, server := net.Pipe()
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	return &wsConn{conn: client, br: bufio.NewReader(client)}, server
+}
+
+// serveHandshake accepts one connection on l and answers its upgrade
+// request with the accept key returned by accept.
+func serveHandshake(t *testing.T, l net.Listener, accept func(key string) string) {
+	t.Helper()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(conn, "HTTP/1.1 101 Switching Protocols\r\n"+
+			"Upgrade: websocket\r\n"+
+			"Connection: Upgrade\r\n"+
+			"Sec-WebSocket-Accept: %s\r\n\r\n", accept(req.Header.Get("Sec-WebSocket-Key")))
+		io.Copy(io.Discard, conn)
+	}()
+}
+
+func TestDialWebsocketChecksAcceptKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		accept  func(key string) string
+		wantErr string
+	}{
+		{name: "valid", accept: acceptKey},
+		{name: "wrong key", accept: func(string) string { return acceptKey("other") }, wantErr: "bad Sec-WebSocket-Accept"},
+		{name: "missing key", accept: func(string) string { return "" }, wantErr: "bad Sec-WebSocket-Accept"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer l.Close()
+			serveHandshake(t, l, tt.accept)
+
+			c, err := dialWebsocket("ws://"+l.Addr().String()+"/websockify?token=x", false, time.Second)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("dialWebsocket: %v", err)
+				}
+				c.conn.Close()
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("dialWebsocket error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAcceptKey(t *testing.T) {
+	// The example from RFC 6455 section 1.3.
+	if got, want := acceptKey("dGhlIHNhbXBsZSBub25jZQ=="), "s3pPLMBiTxaQ9kYGzzhZRbK+xOo="; got != want {
+		t.Fatalf("acceptKey = %q, want %q", got, want)
+	}
+}
+
+func TestWriteMasksFrames(t *testing.T) {
+	for _, size := range []int{0, 5, 125, 126, 0xffff, 0x10000} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			c, server := pipeConn(t)
+			payload := bytes.Repeat([]byte("rfb"), size/3+1)[:size]
+
+			go c.Write(payload)
+
+			// The server side reads with the same decoder, which unmasks.
+			s := &wsConn{conn: server, br: bufio.NewReader(server)}
+			header, err := s.br.Peek(2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if header[0] != 0x80|opBinary {
+				t.Fatalf("first byte = %#x, want final binary frame", header[0])
+			}
+			if header[1]&0x80 == 0 {
+				t.Fatal("client frame is not masked")
+			}
+			opcode, got, err := s.readFrame()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if opcode != opBinary || !bytes.Equal(got, payload) {
+				t.Fatalf("readFrame = %#x, %d bytes, want binary frame of %d bytes", opcode, len(got), len(payload))
+			}
+		})
+	}
+}
+
+func TestReadExtendedLengths(t *testing.T) {
+	for _, size := range []int{125, 126, 300, 0xffff, 0x10000, 70000} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			c, server := pipeConn(t)
+			payload := bytes.Repeat([]byte{0xab}, size)
+			go server.Write(serverFrame(opBinary, payload))
+
+			got := make([]byte, size)
+			if _, err := io.ReadFull(c, got); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, payload) {
+				t.Fatal("payload mismatch")
+			}
+		})
+	}
+}
+
+func TestReadRejectsOversizedFrame(t *testing.T) {
+	c, server := pipeConn(t)
+	header := []byte{0x80 | opBinary, 127, 0, 0, 0, 0, 0, 0, 0, 0}
+	binary.BigEndian.PutUint64(header[2:], 1<<62)
+	go server.Write(header)
+
+	_, err := c.Read(make([]byte, 16))
+	if err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Fatalf("Read error = %v, want a size limit error", err)
+	}
+}
+
+func TestReadAnswersPingAndClose(t *testing.T) {
+	c, server := pipeConn(t)
+	s := &wsConn{conn: server, br: bufio.NewReader(server)}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.Read(make([]byte, 16))
+		done <- err
+	}()
+
+	server.Write(serverFrame(opPing, []byte("hi")))
+	opcode, payload, err := s.readFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opcode != opPong || string(payload) != "hi" {
+		t.Fatalf("reply = %#x %q, want pong %q", opcode, payload, "hi")
+	}
+
+	server.Write(serverFrame(opClose, nil))
+	opcode, _, err = s.readFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opcode != opClose {
+		t.Fatalf("reply = %#x, want close", opcode)
+	}
+	if err := <-done; err != io.EOF {
+		t.Fatalf("Read error = %v, want io.EOF", err)
+	}
+}
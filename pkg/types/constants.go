@@ -18,17 +18,34 @@ package types
 
 // VM Instance States
 const (
-	VMStateRunning   = "Running"
-	VMStateStopped   = "Stopped"
-	VMStatePaused    = "Paused"
-	VMStateDestroyed = "Destroyed"
-	VMStateCreating  = "Creating"
-	VMStateStarting  = "Starting"
-	VMStateStopping  = "Stopping"
-	VMStateRebooting = "Rebooting"
-	VMStateMigrating = "Migrating"
+	VMStateRunning         = "Running"
+	VMStateStopped         = "Stopped"
+	VMStatePaused          = "Paused"
+	VMStateDestroyed       = "Destroyed"
+	VMStateCreating        = "Creating"
+	VMStateStarting        = "Starting"
+	VMStateStopping        = "Stopping"
+	VMStateRebooting       = "Rebooting"
+	VMStateMigrating       = "Migrating"
+	VMStateCreated         = "Created"
+	VMStateDestroying      = "Destroying"
+	VMStateExpunging       = "Expunging"
+	VMStatePausing         = "Pausing"
+	VMStateResuming        = "Resuming"
+	VMStateVolumeMigrating = "VolumeMigrating"
+	VMStateUnknown         = "Unknown"
+	VMStateCrashed         = "Crashed"
+	VMStateNoState         = "NoState"
 )
 
+// VMStates lists every VM instance state
+var VMStates = []string{
+	VMStateCreated, VMStateCreating, VMStateStarting, VMStateRunning, VMStateStopping,
+	VMStateStopped, VMStateRebooting, VMStatePausing, VMStatePaused, VMStateResuming,
+	VMStateMigrating, VMStateVolumeMigrating, VMStateDestroying, VMStateDestroyed,
+	VMStateExpunging, VMStateUnknown, VMStateCrashed, VMStateNoState,
+}
+
 // Image States
 const (
 	ImageStateEnabled  = "Enabled"
@@ -42,12 +59,18 @@ const (
 	ImageStatusDeleted     = "Deleted"
 )
 
+// ImageStatuses lists every image status
+var ImageStatuses = []string{ImageStatusReady, ImageStatusDownloading, ImageStatusDeleted}
+
 // Resource States (generic)
 const (
 	StateEnabled  = "Enabled"
 	StateDisabled = "Disabled"
 )
 
+// States lists the generic resource states
+var States = []string{StateEnabled, StateDisabled}
+
 // IsVMRunnable checks if a VM is in a state that can be started
 func IsVMRunnable(state string) bool {
 	return state == VMStateStopped
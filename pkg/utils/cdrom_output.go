@@ -0,0 +1,44 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+// CdRomRow is the short view of a VM CD-ROM printed after ISO operations.
+type CdRomRow struct {
+	UUID     string  `json:"uuid"     yaml:"uuid"     header:"UUID"`
+	DeviceID float64 `json:"deviceId" yaml:"deviceId" header:"DEVICE"`
+	IsoUUID  string  `json:"isoUuid"  yaml:"isoUuid"  header:"ISO UUID"`
+}
+
+// ConvertCdRoms converts CD-ROMs to CdRomRows.
+func ConvertCdRoms(cdroms []sdkView.VMCDRomView) []CdRomRow {
+	var rows []CdRomRow
+	for _, cdrom := range cdroms {
+		rows = append(rows, CdRomRow{
+			UUID:     cdrom.UUID,
+			DeviceID: cdrom.DeviceId,
+			IsoUUID:  cdrom.IsoUuid,
+		})
+	}
+	return rows
+}
+
+// PrintCdRoms prints CD-ROMs in the given format.
+func PrintCdRoms(cdroms []sdkView.VMCDRomView, format OutputFormat, fields []string) error {
+	return PrintWithFields(ConvertCdRoms(cdroms), format, fields)
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// System tags understood by the guest's cloud-init when a VM is created.
+const (
+	SSHKeyTagPrefix       = "sshkey::"
+	RootPasswordTagPrefix = "rootPassword::"
+)
+
+var sshKeyTypes = []string{"ssh-rsa", "ssh-ed25519", "ssh-dss", "ecdsa-sha2-", "sk-ssh-ed25519", "sk-ecdsa-sha2-"}
+
+// ReadSSHPublicKey reads a single OpenSSH public key from path, which may
+// start with ~/.
+func ReadSSHPublicKey(path string) (string, error) {
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	key := strings.TrimSpace(string(data))
+	if strings.Contains(key, "PRIVATE KEY") {
+		return "", fmt.Errorf("%s is a private key; pass the .pub file", path)
+	}
+	if strings.Contains(key, "\n") {
+		return "", fmt.Errorf("%s contains more than one line; expected a single public key", path)
+	}
+	for _, t := range sshKeyTypes {
+		if strings.HasPrefix(key, t) {
+			return key, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not an OpenSSH public key", path)
+}
+
+// ReadNewPassword prompts twice for a password without echo. When stdin is
+// not a terminal a single line is read instead, so passwords can be piped.
+func ReadNewPassword(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("no password on stdin")
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fmt.Print(prompt)
+	first, err := term.ReadPassword(fd)
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+	fmt.Print("Retype password: ")
+	second, err := term.ReadPassword(fd)
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+	if string(first) != string(second) {
+		return "", fmt.Errorf("passwords do not match")
+	}
+	return string(first), nil
+}
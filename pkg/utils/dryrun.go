@@ -0,0 +1,77 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+// APICall is a request that --dry-run prints instead of sending. Paths are
+// relative to the API endpoint.
+type APICall struct {
+	Method string      `json:"method" yaml:"method"`
+	Path   string      `json:"path" yaml:"path"`
+	Body   interface{} `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+func (c APICall) String() string {
+	if c.Body == nil {
+		return fmt.Sprintf("%s %s", c.Method, c.Path)
+	}
+	body, _ := json.Marshal(c.Body)
+	return fmt.Sprintf("%s %s %s", c.Method, c.Path, body)
+}
+
+// NewAPICall is a request of method to path. body is stored as its JSON
+// form, so YAML output shows the same keys as the API.
+func NewAPICall(method, path string, body interface{}) APICall {
+	var normalized interface{}
+	if data, err := json.Marshal(body); err == nil && json.Unmarshal(data, &normalized) == nil {
+		body = normalized
+	}
+	return APICall{Method: method, Path: path, Body: body}
+}
+
+// DeleteCall is the request that deletes the resource uuid under path.
+func DeleteCall(path, uuid string, mode param.DeleteMode) APICall {
+	return NewAPICall(http.MethodDelete, fmt.Sprintf("%s/%s?deleteMode=%s", path, uuid, mode), nil)
+}
+
+// DeletePathCall is the DELETE request to path, which includes any query
+// parameters.
+func DeletePathCall(path string) APICall {
+	return NewAPICall(http.MethodDelete, path, nil)
+}
+
+// ActionCall is the request that runs the action in body on the resource
+// uuid under path.
+func ActionCall(path, uuid string, body interface{}) APICall {
+	return NewAPICall(http.MethodPut, fmt.Sprintf("%s/%s/actions", path, uuid), body)
+}
+
+// PostCall is the request that creates or attaches something at path.
+func PostCall(path string, body interface{}) APICall {
+	return NewAPICall(http.MethodPost, path, body)
+}
+
+// EmptyAction is the body of an action without parameters, e.g.
+// {"expungeVmInstance": {}}.
+func EmptyAction(action string) map[string]interface{} {
+	return map[string]interface{}{action: map[string]interface{}{}}
+}
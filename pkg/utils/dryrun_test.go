@@ -0,0 +1,96 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/param"
+)
+
+func TestAPICalls(t *testing.T) {
+	type params struct {
+		Name string `json:"name"`
+		Size int64  `json:"size,omitempty"`
+	}
+
+	tests := []struct {
+		name string
+		call APICall
+		want APICall
+		str  string
+	}{
+		{
+			name: "delete",
+			call: DeleteCall("v1/vm-instances", "abc", param.DeleteModePermissive),
+			want: APICall{Method: "DELETE", Path: "v1/vm-instances/abc?deleteMode=Permissive"},
+			str:  "DELETE v1/vm-instances/abc?deleteMode=Permissive",
+		},
+		{
+			name: "delete enforcing",
+			call: DeleteCall("v1/volumes", "abc", param.DeleteModeEnforcing),
+			want: APICall{Method: "DELETE", Path: "v1/volumes/abc?deleteMode=Enforcing"},
+			str:  "DELETE v1/volumes/abc?deleteMode=Enforcing",
+		},
+		{
+			name: "delete path",
+			call: DeletePathCall("v1/vm-instances/abc/volumes/def"),
+			want: APICall{Method: "DELETE", Path: "v1/vm-instances/abc/volumes/def"},
+			str:  "DELETE v1/vm-instances/abc/volumes/def",
+		},
+		{
+			name: "action",
+			call: ActionCall("v1/vm-instances", "abc", EmptyAction("stopVmInstance")),
+			want: APICall{
+				Method: "PUT",
+				Path:   "v1/vm-instances/abc/actions",
+				Body:   map[string]interface{}{"stopVmInstance": map[string]interface{}{}},
+			},
+			str: `PUT v1/vm-instances/abc/actions {"stopVmInstance":{}}`,
+		},
+		{
+			name: "post uses JSON keys",
+			call: PostCall("v1/volumes/data", map[string]interface{}{"params": params{Name: "data"}}),
+			want: APICall{
+				Method: "POST",
+				Path:   "v1/volumes/data",
+				Body:   map[string]interface{}{"params": map[string]interface{}{"name": "data"}},
+			},
+			str: `POST v1/volumes/data {"params":{"name":"data"}}`,
+		},
+		{
+			name: "numbers become float64",
+			call: NewAPICall("PUT", "v1/volumes/abc/actions", map[string]interface{}{"resizeDataVolume": params{Name: "data", Size: 1024}}),
+			want: APICall{
+				Method: "PUT",
+				Path:   "v1/volumes/abc/actions",
+				Body:   map[string]interface{}{"resizeDataVolume": map[string]interface{}{"name": "data", "size": float64(1024)}},
+			},
+			str: `PUT v1/volumes/abc/actions {"resizeDataVolume":{"name":"data","size":1024}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.call, tt.want) {
+				t.Errorf("call = %#v, want %#v", tt.call, tt.want)
+			}
+			if got := tt.call.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/terraform-zstack-modules/zstack-sdk-go/pkg/util/httputils"
+)
+
+// ErrorCodeUnknown is reported when no code can be extracted from an error.
+const ErrorCodeUnknown = "UNKNOWN"
+
+// ErrorCodeTimeout is reported for errors that have a Timeout method
+// returning true, such as a wait or batch item that ran out of time.
+const ErrorCodeTimeout = "TIMEOUT"
+
+// zstackErrorCode matches the error code embedded in ZStack API replies,
+// e.g. "code":"SYS.1006" or "code":"VM.1001".
+var zstackErrorCode = regexp.MustCompile(`"code"\s*:\s*"([A-Z0-9_]+\.[0-9]+)"`)
+
+// ErrorCode extracts a stable code from an SDK error for machine-readable output.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if m := zstackErrorCode.FindStringSubmatch(err.Error()); m != nil {
+		return m[1]
+	}
+
+	var jce *httputils.JSONClientError
+	if errors.As(err, &jce) {
+		if jce.Class != "" {
+			return jce.Class
+		}
+		if jce.Code != 0 {
+			return strconv.Itoa(jce.Code)
+		}
+	}
+
+	var te interface{ Timeout() bool }
+	if errors.As(err, &te) && te.Timeout() {
+		return ErrorCodeTimeout
+	}
+
+	return ErrorCodeUnknown
+}
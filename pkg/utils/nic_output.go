@@ -0,0 +1,50 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+// NicRow is the short view of a VM NIC printed after NIC operations.
+type NicRow struct {
+	UUID          string `json:"uuid"          yaml:"uuid"          header:"UUID"`
+	DeviceID      int    `json:"deviceId"      yaml:"deviceId"      header:"DEVICE ID"`
+	L3NetworkUUID string `json:"l3NetworkUuid" yaml:"l3NetworkUuid" header:"L3 NETWORK UUID"`
+	IP            string `json:"ip"            yaml:"ip"            header:"IP"`
+	Mac           string `json:"mac"           yaml:"mac"           header:"MAC"`
+	Default       bool   `json:"default"       yaml:"default"       header:"DEFAULT"`
+}
+
+// ConvertVmNics converts the NICs of vm to NicRows.
+func ConvertVmNics(vm sdkView.VmInstanceInventoryView) []NicRow {
+	var rows []NicRow
+	for _, nic := range vm.VMNics {
+		rows = append(rows, NicRow{
+			UUID:          nic.UUID,
+			DeviceID:      nic.DeviceID,
+			L3NetworkUUID: nic.L3NetworkUUID,
+			IP:            nic.IP,
+			Mac:           nic.Mac,
+			Default:       nic.L3NetworkUUID == vm.DefaultL3NetworkUUID,
+		})
+	}
+	return rows
+}
+
+// PrintVmNics prints the NICs of vm in the given format.
+func PrintVmNics(vm sdkView.VmInstanceInventoryView, format OutputFormat, fields []string) error {
+	return PrintWithFields(ConvertVmNics(vm), format, fields)
+}
@@ -35,6 +35,9 @@ const (
 	JSONFormat  OutputFormat = "json"
 	YAMLFormat  OutputFormat = "yaml"
 	TextFormat  OutputFormat = "text"
+	WideFormat  OutputFormat = "wide"
+
+	JSONLinesFormat OutputFormat = "jsonl"
 )
 
 type Formatter interface {
@@ -51,6 +54,10 @@ func GetFormatter(format OutputFormat) Formatter {
 		return &YAMLFormatter{}
 	case TextFormat:
 		return &TextFormatter{}
+	case WideFormat:
+		return &TableFormatter{Wide: true}
+	case JSONLinesFormat:
+		return &JSONLinesFormatter{}
 	default:
 		return &TableFormatter{}
 	}
@@ -76,6 +83,41 @@ func (f *JSONFormatter) Format(data interface{}, fields []string) error {
 	return nil
 }
 
+// JSONLinesFormatter prints each element of a slice as one compact JSON
+// object per line. Other values are printed as a single line.
+type JSONLinesFormatter struct{}
+
+func (f *JSONLinesFormatter) Format(data interface{}, fields []string) error {
+	if len(fields) > 0 {
+		filteredData, err := filterFields(data, fields)
+		if err != nil {
+			return err
+		}
+		data = filteredData
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return printJSONLine(data)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		if err := printJSONLine(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printJSONLine(data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
 type YAMLFormatter struct{}
 
 func (f *YAMLFormatter) Format(data interface{}, fields []string) error {
@@ -110,7 +152,14 @@ func (f *TextFormatter) Format(data interface{}, fields []string) error {
 	return nil
 }
 
-type TableFormatter struct{}
+// TableFormatter renders data as a table. Columns tagged `json:",omitempty"`
+// are hidden when empty in every row. Unless Wide is set, a column tagged
+// `resolve:"OtherField"` is also hidden once OtherField holds a value, so a
+// resolved name can take the place of its UUID. Explicitly requested fields
+// are always shown.
+type TableFormatter struct {
+	Wide bool
+}
 
 func (f *TableFormatter) Format(data interface{}, fields []string) error {
 	v := reflect.ValueOf(data)
@@ -121,7 +170,7 @@ func (f *TableFormatter) Format(data interface{}, fields []string) error {
 
 	switch v.Kind() {
 	case reflect.Slice, reflect.Array:
-		return formatSlice(v.Interface(), fields)
+		return formatSlice(v.Interface(), fields, f.Wide)
 	case reflect.Map:
 		return formatMap(v.Interface(), fields)
 	case reflect.Struct:
@@ -132,7 +181,7 @@ func (f *TableFormatter) Format(data interface{}, fields []string) error {
 	}
 }
 
-func formatSlice(data interface{}, fields []string) error {
+func formatSlice(data interface{}, fields []string, wide bool) error {
 	v := reflect.ValueOf(data)
 
 	if v.Len() == 0 {
@@ -143,7 +192,7 @@ func formatSlice(data interface{}, fields []string) error {
 	firstElem := v.Index(0)
 
 	if firstElem.Kind() == reflect.Struct {
-		return formatStructSlice(data, fields)
+		return formatStructSlice(data, fields, wide)
 	} else if firstElem.Kind() == reflect.Map {
 		return formatMapSlice(data, fields)
 	}
@@ -160,13 +209,37 @@ func formatSlice(data interface{}, fields []string) error {
 	return nil
 }
 
-func formatStructSlice(data interface{}, fields []string) error {
+func formatStructSlice(data interface{}, fields []string, wide bool) error {
 	v := reflect.ValueOf(data)
 	if v.Len() == 0 {
 		return nil
 	}
 
-	elemType := reflect.TypeOf(v.Index(0).Interface())
+	headers, fieldIndices := tableColumns(v, fields, wide)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header(headers)
+
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i)
+		var row []string
+
+		for _, idx := range fieldIndices {
+			fieldValue := item.Field(idx)
+			row = append(row, fmt.Sprintf("%v", fieldValue.Interface()))
+		}
+
+		table.Append(row)
+	}
+
+	table.Render()
+	return nil
+}
+
+// tableColumns selects the headers and struct field indices shown for the
+// struct slice v.
+func tableColumns(v reflect.Value, fields []string, wide bool) ([]string, []int) {
+	elemType := v.Type().Elem()
 
 	var headers []string
 	var fieldIndices []int
@@ -179,6 +252,16 @@ func formatStructSlice(data interface{}, fields []string) error {
 
 			tagParts := strings.Split(tagName, ",")
 			fieldName = tagParts[0]
+
+			if len(tagParts) > 1 && tagParts[1] == "omitempty" && len(fields) == 0 && columnIsEmpty(v, i) {
+				continue
+			}
+		}
+
+		if resolved := field.Tag.Get("resolve"); resolved != "" && !wide && len(fields) == 0 {
+			if nameField, ok := elemType.FieldByName(resolved); ok && !columnIsEmpty(v, nameField.Index[0]) {
+				continue
+			}
 		}
 
 		if len(fields) > 0 {
@@ -198,23 +281,18 @@ func formatStructSlice(data interface{}, fields []string) error {
 		fieldIndices = append(fieldIndices, i)
 	}
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.Header(headers)
+	return headers, fieldIndices
+}
 
+// columnIsEmpty reports whether struct field idx is the zero value in every
+// element of the slice v.
+func columnIsEmpty(v reflect.Value, idx int) bool {
 	for i := 0; i < v.Len(); i++ {
-		item := v.Index(i)
-		var row []string
-
-		for _, idx := range fieldIndices {
-			fieldValue := item.Field(idx)
-			row = append(row, fmt.Sprintf("%v", fieldValue.Interface()))
+		if !v.Index(i).Field(idx).IsZero() {
+			return false
 		}
-
-		table.Append(row)
 	}
-
-	table.Render()
-	return nil
+	return true
 }
 
 func formatMapSlice(data interface{}, fields []string) error {
@@ -533,6 +611,10 @@ func ParseFormat(format string) OutputFormat {
 		return TextFormat
 	case "table":
 		return TableFormat
+	case "wide":
+		return WideFormat
+	case "jsonl":
+		return JSONLinesFormat
 	default:
 		return TableFormat
 	}
@@ -547,6 +629,12 @@ func PrintDryRun(data interface{}, format string) {
 		yamlData, _ := yaml.Marshal(data)
 		fmt.Println(string(yamlData))
 	default:
+		if calls, ok := data.([]APICall); ok {
+			for _, c := range calls {
+				fmt.Println(c)
+			}
+			return
+		}
 		fmt.Printf("Would create with parameters: %+v\n", data)
 	}
 }
@@ -0,0 +1,113 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+// ResourceRow is the short view of a resource of any type.
+type ResourceRow struct {
+	Name  string `json:"name"            yaml:"name"            header:"NAME"`
+	UUID  string `json:"uuid"            yaml:"uuid"            header:"UUID"`
+	State string `json:"state,omitempty" yaml:"state,omitempty" header:"STATE"`
+}
+
+// ConvertResource converts a raw inventory to a ResourceRow. The state is
+// taken from the state or status field, whichever the resource has.
+func ConvertResource(inventory map[string]interface{}) ResourceRow {
+	row := ResourceRow{
+		Name: stringField(inventory, "name"),
+		UUID: stringField(inventory, "uuid"),
+	}
+	row.State = stringField(inventory, "state")
+	if status := stringField(inventory, "status"); status != "" {
+		row.State = status
+	}
+	return row
+}
+
+// ResourceBatchResult is BatchResult for resources other than VMs.
+type ResourceBatchResult struct {
+	Operation string         `json:"operation" yaml:"operation"`
+	Resource  string         `json:"resource"  yaml:"resource"`
+	Summary   BatchCounts    `json:"summary"   yaml:"summary"`
+	Succeeded []ResourceRow  `json:"succeeded" yaml:"succeeded"`
+	Failed    []BatchFailure `json:"failed"    yaml:"failed"`
+	Skipped   []BatchSkip    `json:"skipped"   yaml:"skipped"`
+
+	done string
+}
+
+// NewResourceBatchResult creates an empty result for operation on resource,
+// e.g. "delete" on "l3-networks". done is the past tense used in the text
+// summary.
+func NewResourceBatchResult(operation, resource, done string) *ResourceBatchResult {
+	return &ResourceBatchResult{
+		Operation: operation,
+		Resource:  resource,
+		Succeeded: []ResourceRow{},
+		Failed:    []BatchFailure{},
+		Skipped:   []BatchSkip{},
+		done:      done,
+	}
+}
+
+// Succeed records inventory as succeeded.
+func (r *ResourceBatchResult) Succeed(inventory map[string]interface{}) {
+	r.Succeeded = append(r.Succeeded, ConvertResource(inventory))
+	r.Summary.Succeeded = len(r.Succeeded)
+}
+
+// Fail records inventory as failed with err.
+func (r *ResourceBatchResult) Fail(inventory map[string]interface{}, err error) {
+	row := ConvertResource(inventory)
+	r.Failed = append(r.Failed, BatchFailure{
+		Name:    row.Name,
+		UUID:    row.UUID,
+		Code:    ErrorCode(err),
+		Message: err.Error(),
+	})
+	r.Summary.Failed = len(r.Failed)
+}
+
+// Skip records inventory as skipped for reason.
+func (r *ResourceBatchResult) Skip(inventory map[string]interface{}, reason string) {
+	row := ConvertResource(inventory)
+	r.Skipped = append(r.Skipped, BatchSkip{
+		Name:   row.Name,
+		UUID:   row.UUID,
+		State:  row.State,
+		Reason: reason,
+	})
+	r.Summary.Skipped = len(r.Skipped)
+}
+
+// PrintResourceSummary prints the outcome of a batch operation like
+// PrintSummary does for VMs.
+func PrintResourceSummary(result *ResourceBatchResult, format OutputFormat, fields []string) error {
+	if IsStructuredFormat(format) {
+		return Print(result, format)
+	}
+
+	printBatchCounts(len(result.Succeeded), result.done, result.Failed, len(result.Skipped))
+	if len(result.Succeeded) == 0 {
+		return nil
+	}
+	return PrintWithFields(result.Succeeded, format, fields)
+}
+
+func stringField(inventory map[string]interface{}, key string) string {
+	if s, ok := inventory[key].(string); ok {
+		return s
+	}
+	return ""
+}
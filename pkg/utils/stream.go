@@ -0,0 +1,175 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StreamPrinter prints a result set one page at a time, so large inventories
+// never have to be held in memory. JSON output is written as a single array,
+// YAML as a single sequence and tables share one header whose column widths
+// are taken from the first page.
+type StreamPrinter struct {
+	format OutputFormat
+	fields []string
+
+	rows    int
+	columns []int
+	widths  []int
+}
+
+func NewStreamPrinter(format OutputFormat, fields []string) *StreamPrinter {
+	return &StreamPrinter{format: format, fields: fields}
+}
+
+// Print writes one page. page is expected to be a slice.
+func (p *StreamPrinter) Print(page interface{}) error {
+	if len(p.fields) > 0 {
+		filteredData, err := filterFields(page, p.fields)
+		if err != nil {
+			return err
+		}
+		page = filteredData
+	}
+
+	v := reflect.ValueOf(page)
+	if v.Kind() != reflect.Slice || v.Len() == 0 {
+		return nil
+	}
+
+	switch p.format {
+	case JSONFormat:
+		for i := 0; i < v.Len(); i++ {
+			jsonData, err := json.MarshalIndent(v.Index(i).Interface(), "  ", "  ")
+			if err != nil {
+				return err
+			}
+			if p.rows == 0 {
+				fmt.Print("[\n  ")
+			} else {
+				fmt.Print(",\n  ")
+			}
+			fmt.Print(string(jsonData))
+			p.rows++
+		}
+		return nil
+	case JSONLinesFormat:
+		p.rows += v.Len()
+		return (&JSONLinesFormatter{}).Format(page, nil)
+	case YAMLFormat:
+		yamlData, err := yaml.Marshal(page)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(yamlData))
+		p.rows += v.Len()
+		return nil
+	case TextFormat:
+		for i := 0; i < v.Len(); i++ {
+			fmt.Printf("%v\n", v.Index(i).Interface())
+		}
+		p.rows += v.Len()
+		return nil
+	default:
+		return p.printTableRows(v)
+	}
+}
+
+// Close finishes the output. It must be called once after the last page.
+func (p *StreamPrinter) Close() error {
+	switch p.format {
+	case JSONFormat:
+		if p.rows == 0 {
+			fmt.Println("[]")
+		} else {
+			fmt.Print("\n]\n")
+		}
+	case YAMLFormat:
+		if p.rows == 0 {
+			fmt.Println("[]")
+		}
+	case JSONLinesFormat, TextFormat:
+	default:
+		if p.rows == 0 {
+			fmt.Println("No resources found.")
+		}
+	}
+	return nil
+}
+
+func (p *StreamPrinter) printTableRows(v reflect.Value) error {
+	if v.Index(0).Kind() != reflect.Struct {
+		for i := 0; i < v.Len(); i++ {
+			fmt.Printf("%v\n", v.Index(i).Interface())
+		}
+		p.rows += v.Len()
+		return nil
+	}
+
+	rows := make([][]string, 0, v.Len()+1)
+	if p.columns == nil {
+		headers, columns := tableColumns(v, p.fields, p.format == WideFormat)
+		p.columns = columns
+		p.widths = make([]int, len(columns))
+
+		header := make([]string, len(headers))
+		for i, h := range headers {
+			header[i] = strings.ToUpper(h)
+		}
+		rows = append(rows, header)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i)
+		row := make([]string, len(p.columns))
+		for j, idx := range p.columns {
+			row[j] = fmt.Sprintf("%v", item.Field(idx).Interface())
+		}
+		rows = append(rows, row)
+	}
+
+	// Widths only grow while the first page is printed; later pages reuse
+	// them so that columns stay aligned without buffering the whole result.
+	if p.rows == 0 {
+		for _, row := range rows {
+			for j, cell := range row {
+				if len(cell) > p.widths[j] {
+					p.widths[j] = len(cell)
+				}
+			}
+		}
+	}
+
+	for _, row := range rows {
+		var line strings.Builder
+		for j, cell := range row {
+			if j == len(row)-1 {
+				line.WriteString(cell)
+				break
+			}
+			line.WriteString(fmt.Sprintf("%-*s   ", p.widths[j], cell))
+		}
+		fmt.Println(strings.TrimRight(line.String(), " "))
+	}
+
+	p.rows += v.Len()
+	return nil
+}
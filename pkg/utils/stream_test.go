@@ -0,0 +1,154 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type streamRow struct {
+	Name  string `json:"name" yaml:"name"`
+	State string `json:"state" yaml:"state"`
+}
+
+// captureStdout returns what fn writes to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
+
+// streamPages prints pages with a StreamPrinter and returns the output.
+func streamPages(t *testing.T, format OutputFormat, fields []string, pages ...[]streamRow) string {
+	t.Helper()
+	return captureStdout(t, func() {
+		p := NewStreamPrinter(format, fields)
+		for _, page := range pages {
+			if err := p.Print(page); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := p.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+var (
+	streamPage1 = []streamRow{{"web-1", "Running"}, {"db", "Stopped"}}
+	streamPage2 = []streamRow{{"web-long-name", "Running"}}
+	streamAll   = append(append([]streamRow{}, streamPage1...), streamPage2...)
+)
+
+func TestStreamPrinterJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages [][]streamRow
+		want  []streamRow
+	}{
+		{name: "several pages", pages: [][]streamRow{streamPage1, nil, streamPage2}, want: streamAll},
+		{name: "no rows", pages: nil, want: []streamRow{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := streamPages(t, JSONFormat, nil, tt.pages...)
+			var got []streamRow
+			if err := json.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("output is not a JSON array: %v\n%s", err, out)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rows = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamPrinterYAML(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages [][]streamRow
+		want  []streamRow
+	}{
+		{name: "several pages", pages: [][]streamRow{streamPage1, streamPage2}, want: streamAll},
+		{name: "no rows", pages: nil, want: []streamRow{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := streamPages(t, YAMLFormat, nil, tt.pages...)
+			var got []streamRow
+			if err := yaml.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("output is not a YAML sequence: %v\n%s", err, out)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rows = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamPrinterTable(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		pages  [][]streamRow
+		want   string
+	}{
+		{
+			name:  "one header, widths from the first page",
+			pages: [][]streamRow{streamPage1, streamPage2},
+			want: "NAME    STATE\n" +
+				"web-1   Running\n" +
+				"db      Stopped\n" +
+				"web-long-name   Running\n",
+		},
+		{
+			name:   "fields",
+			fields: []string{"state"},
+			pages:  [][]streamRow{streamPage1},
+			want:   "STATE\nRunning\nStopped\n",
+		},
+		{
+			name: "no rows",
+			want: "No resources found.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := streamPages(t, TableFormat, tt.fields, tt.pages...); got != tt.want {
+				t.Errorf("output =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UserDataTagPrefix is the system tag carrying base64 encoded cloud-init
+// user data.
+const UserDataTagPrefix = "userdata::"
+
+// MaxUserDataSize is the largest user data accepted, before encoding.
+// cloud-init datasources commonly cap user data at 16 KiB.
+const MaxUserDataSize = 16 * 1024
+
+// user data formats cloud-init recognises by their first line.
+var userDataHeaders = []string{"#cloud-config", "#!", "#include", "#cloud-boothook", "#part-handler", "#upstart-job", "Content-Type:"}
+
+// ValidateUserData checks the size of cloud-init user data and, for
+// #cloud-config documents, that they are a valid YAML mapping.
+func ValidateUserData(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("user data is empty")
+	}
+	if len(data) > MaxUserDataSize {
+		return fmt.Errorf("user data is %d bytes; the limit is %d bytes", len(data), MaxUserDataSize)
+	}
+
+	known := false
+	for _, h := range userDataHeaders {
+		if bytes.HasPrefix(data, []byte(h)) {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("user data must start with a cloud-init header such as '#cloud-config' or '#!'")
+	}
+
+	if !bytes.HasPrefix(data, []byte("#cloud-config")) {
+		return nil
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid cloud-config YAML: %v", err)
+	}
+	if doc == nil {
+		return nil
+	}
+	if _, ok := doc.(map[string]interface{}); !ok {
+		return fmt.Errorf("invalid cloud-config: the document must be a mapping")
+	}
+	return nil
+}
+
+// UserDataSystemTag returns the system tag that passes data to cloud-init.
+func UserDataSystemTag(data []byte) string {
+	return UserDataTagPrefix + base64.StdEncoding.EncodeToString(data)
+}
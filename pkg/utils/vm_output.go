@@ -3,6 +3,8 @@ package utils
 // cmdutils/vm_output.go
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
@@ -47,21 +49,118 @@ func PrintVMs(vms []sdkView.VmInstanceInventoryView, format OutputFormat, fields
 	return PrintWithFields(rows, format, fields)
 }
 
-// PrintSummary
-func PrintSummary(successes, failures, skipped []sdkView.VmInstanceInventoryView) {
-	fmt.Printf("\nSummary: %d started, %d failed, %d skipped\n",
-		len(successes), len(failures), len(skipped))
+// BatchFailure describes a resource whose operation returned an error.
+type BatchFailure struct {
+	Name    string `json:"name"    yaml:"name"`
+	UUID    string `json:"uuid"    yaml:"uuid"`
+	Code    string `json:"code"    yaml:"code"`
+	Message string `json:"message" yaml:"message"`
+}
 
-	if len(failures) > 0 {
-		fmt.Println("Failures:")
-		for _, f := range failures {
-			fmt.Printf("  - %s (%s)\n", f.Name, f.UUID)
-		}
+// BatchSkip describes a matched resource that was not acted upon.
+type BatchSkip struct {
+	Name   string `json:"name"   yaml:"name"`
+	UUID   string `json:"uuid"   yaml:"uuid"`
+	State  string `json:"state"  yaml:"state"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// BatchCounts holds the size of each bucket of a BatchResult.
+type BatchCounts struct {
+	Succeeded int `json:"succeeded" yaml:"succeeded"`
+	Failed    int `json:"failed"    yaml:"failed"`
+	Skipped   int `json:"skipped"   yaml:"skipped"`
+}
+
+// BatchResult is the machine-readable outcome of a batch VM operation.
+type BatchResult struct {
+	Operation string         `json:"operation" yaml:"operation"`
+	Summary   BatchCounts    `json:"summary"   yaml:"summary"`
+	Succeeded []VMRow        `json:"succeeded" yaml:"succeeded"`
+	Failed    []BatchFailure `json:"failed"    yaml:"failed"`
+	Skipped   []BatchSkip    `json:"skipped"   yaml:"skipped"`
+
+	done string
+}
+
+// NewBatchResult creates an empty result for operation. done is the past
+// tense used in the text summary, e.g. "stopped".
+func NewBatchResult(operation, done string) *BatchResult {
+	return &BatchResult{
+		Operation: operation,
+		Succeeded: []VMRow{},
+		Failed:    []BatchFailure{},
+		Skipped:   []BatchSkip{},
+		done:      done,
+	}
+}
+
+// Succeed records vm as succeeded.
+func (r *BatchResult) Succeed(vm sdkView.VmInstanceInventoryView) {
+	r.Succeeded = append(r.Succeeded, ConvertVMs([]sdkView.VmInstanceInventoryView{vm})...)
+	r.Summary.Succeeded = len(r.Succeeded)
+}
+
+// Fail records vm as failed with err.
+func (r *BatchResult) Fail(vm sdkView.VmInstanceInventoryView, err error) {
+	r.Failed = append(r.Failed, BatchFailure{
+		Name:    vm.Name,
+		UUID:    vm.UUID,
+		Code:    ErrorCode(err),
+		Message: err.Error(),
+	})
+	r.Summary.Failed = len(r.Failed)
+}
+
+// Skip records vm as skipped for reason.
+func (r *BatchResult) Skip(vm sdkView.VmInstanceInventoryView, reason string) {
+	r.Skipped = append(r.Skipped, BatchSkip{
+		Name:   vm.Name,
+		UUID:   vm.UUID,
+		State:  vm.State,
+		Reason: reason,
+	})
+	r.Summary.Skipped = len(r.Skipped)
+}
+
+// IsStructuredFormat reports whether format is meant to be parsed by
+// programs rather than read by people.
+func IsStructuredFormat(format OutputFormat) bool {
+	return format == JSONFormat || format == JSONLinesFormat || format == YAMLFormat
+}
+
+// StatusWriter returns where progress messages should go for format.
+// Structured output keeps stdout clean so it can be piped to a parser.
+func StatusWriter(format OutputFormat) io.Writer {
+	if IsStructuredFormat(format) {
+		return os.Stderr
 	}
-	if len(skipped) > 0 {
-		fmt.Println("Skipped (not Stopped):")
-		for _, s := range skipped {
-			fmt.Printf("  - %s (%s) state=%s\n", s.Name, s.UUID, s.State)
+	return os.Stdout
+}
+
+// PrintSummary prints the outcome of a batch operation. JSON and YAML
+// produce a single envelope; other formats print a text summary followed
+// by the succeeded VMs.
+func PrintSummary(result *BatchResult, format OutputFormat, fields []string) error {
+	if IsStructuredFormat(format) {
+		return Print(result, format)
+	}
+
+	printBatchCounts(len(result.Succeeded), result.done, result.Failed, len(result.Skipped))
+	if len(result.Succeeded) == 0 {
+		return nil
+	}
+	return PrintWithFields(result.Succeeded, format, fields)
+}
+
+// printBatchCounts prints the text summary line of a batch and its failures.
+func printBatchCounts(succeeded int, done string, failed []BatchFailure, skipped int) {
+	fmt.Printf("\nSummary: %d %s, %d failed, %d skipped\n", succeeded, done, len(failed), skipped)
+
+	if len(failed) > 0 {
+		fmt.Println("Failures:")
+		for _, f := range failed {
+			fmt.Printf("  - %s (%s): [%s] %s\n", f.Name, f.UUID, f.Code, f.Message)
 		}
 	}
 }
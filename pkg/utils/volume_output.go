@@ -0,0 +1,48 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	sdkView "github.com/terraform-zstack-modules/zstack-sdk-go/pkg/view"
+)
+
+// VolumeRow is the short view of a volume printed after volume operations.
+type VolumeRow struct {
+	Name           string `json:"name"           yaml:"name"           header:"NAME"`
+	UUID           string `json:"uuid"           yaml:"uuid"           header:"UUID"`
+	Size           string `json:"size"           yaml:"size"           header:"SIZE"`
+	Status         string `json:"status"         yaml:"status"         header:"STATUS"`
+	VMInstanceUUID string `json:"vmInstanceUuid" yaml:"vmInstanceUuid" header:"VM INSTANCE UUID"`
+}
+
+// ConvertVolumes converts volumes to VolumeRows.
+func ConvertVolumes(volumes []sdkView.VolumeView) []VolumeRow {
+	var rows []VolumeRow
+	for _, vol := range volumes {
+		rows = append(rows, VolumeRow{
+			Name:           vol.Name,
+			UUID:           vol.UUID,
+			Size:           FormatMemorySize(int64(vol.Size)),
+			Status:         vol.Status,
+			VMInstanceUUID: vol.VMInstanceUUID,
+		})
+	}
+	return rows
+}
+
+// PrintVolumes prints volumes in the given format.
+func PrintVolumes(volumes []sdkView.VolumeView, format OutputFormat, fields []string) error {
+	return PrintWithFields(ConvertVolumes(volumes), format, fields)
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 zstack.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WatchRow is one resource in the output of a get command.
+type WatchRow struct {
+	// Key identifies the resource across queries: its UUID, or its name
+	// for resources without one.
+	Key    string
+	Name   string
+	Values map[string]string
+	Object interface{}
+}
+
+// Label returns the name and UUID of the resource for status lines.
+func (r WatchRow) Label() string {
+	if r.Name == "" || r.Name == r.Key {
+		return r.Key
+	}
+	return fmt.Sprintf("%s (%s)", r.Name, r.Key)
+}
+
+// Changes lists the columns whose values differ from prev, in columns
+// order, as "column: old -> new".
+func (r WatchRow) Changes(prev WatchRow, columns []string) []string {
+	var changes []string
+	for _, col := range columns {
+		if prev.Values[col] != r.Values[col] {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", col, prev.Values[col], r.Values[col]))
+		}
+	}
+	return changes
+}
+
+// WatchRows splits printable data into rows with the columns the table
+// formatter would show.
+func WatchRows(data interface{}, fields []string, wide bool) ([]string, []WatchRow) {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		s := reflect.MakeSlice(reflect.SliceOf(v.Type()), 0, 1)
+		v = reflect.Append(s, v)
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, nil
+	}
+
+	var columns []string
+	var rows []WatchRow
+	switch v.Type().Elem().Kind() {
+	case reflect.Struct:
+		var indices []int
+		columns, indices = tableColumns(v, fields, wide)
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			row := WatchRow{Values: map[string]string{}, Object: item.Interface()}
+			for n, idx := range indices {
+				row.Values[columns[n]] = fmt.Sprintf("%v", item.Field(idx).Interface())
+			}
+			if f := item.FieldByName("UUID"); f.IsValid() {
+				row.Key = fmt.Sprintf("%v", f.Interface())
+			}
+			if f := item.FieldByName("Name"); f.IsValid() {
+				row.Name = fmt.Sprintf("%v", f.Interface())
+			}
+			rows = append(rows, keyedRow(row, columns))
+		}
+	case reflect.Map:
+		keys := map[string]bool{}
+		for i := 0; i < v.Len(); i++ {
+			item, _ := v.Index(i).Interface().(map[string]interface{})
+			row := WatchRow{Values: map[string]string{}, Object: item}
+			for key, val := range item {
+				if !fieldSelected(fields, key) {
+					continue
+				}
+				keys[key] = true
+				row.Values[key] = fmt.Sprintf("%v", val)
+			}
+			row.Key = fmt.Sprintf("%v", valueOrEmpty(item, "uuid"))
+			row.Name = fmt.Sprintf("%v", valueOrEmpty(item, "name"))
+			rows = append(rows, row)
+		}
+		for key := range keys {
+			columns = append(columns, key)
+		}
+		sort.Strings(columns)
+		for i := range rows {
+			rows[i] = keyedRow(rows[i], columns)
+		}
+	}
+	return columns, rows
+}
+
+// keyedRow falls back to the name, then to the whole row, when a resource
+// has no UUID.
+func keyedRow(row WatchRow, columns []string) WatchRow {
+	if row.Key == "" {
+		row.Key = row.Name
+	}
+	if row.Key == "" {
+		var values []string
+		for _, col := range columns {
+			values = append(values, row.Values[col])
+		}
+		row.Key = strings.Join(values, " ")
+	}
+	return row
+}
+
+func fieldSelected(fields []string, key string) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func valueOrEmpty(m map[string]interface{}, key string) interface{} {
+	if v, ok := m[key]; ok && v != nil {
+		return v
+	}
+	return ""
+}